@@ -0,0 +1,147 @@
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"gitlab.com/yawning/secp256k1-voi"
+)
+
+// Network holds the address prefixes of a Bitcoin network.
+type Network struct {
+	Name string
+	// PubKeyHashPrefix is the version byte of P2PKH addresses.
+	PubKeyHashPrefix byte
+	// ScriptHashPrefix is the version byte of P2SH addresses.
+	ScriptHashPrefix byte
+	// Bech32Prefix is the human readable part of segwit addresses.
+	Bech32Prefix string
+	// Coin is the SLIP-0044 coin type used in derivation paths.
+	Coin uint32
+}
+
+// The Bitcoin networks. Regtest has its own bech32 prefix but otherwise
+// shares the testnet prefixes.
+var (
+	MainNet = &Network{"mainnet", 0x00, 0x05, "bc", CoinBitcoin}
+	TestNet = &Network{"testnet", 0x6f, 0xc4, "tb", CoinTestnet}
+	RegTest = &Network{"regtest", 0x6f, 0xc4, "bcrt", CoinTestnet}
+)
+
+// AddressType is a kind of single key Bitcoin address.
+type AddressType int
+
+const (
+	// P2PKH is a legacy pay-to-public-key-hash address, starting with 1 on
+	// mainnet.
+	P2PKH AddressType = iota
+	// P2SHP2WPKH is a segwit address nested in pay-to-script-hash, starting
+	// with 3 on mainnet.
+	P2SHP2WPKH
+	// P2WPKH is a native segwit address, starting with bc1q on mainnet.
+	P2WPKH
+	// P2TR is a taproot address, starting with bc1p on mainnet.
+	P2TR
+)
+
+func (t AddressType) String() string {
+	switch t {
+	case P2PKH:
+		return "P2PKH"
+	case P2SHP2WPKH:
+		return "P2SH-P2WPKH"
+	case P2WPKH:
+		return "P2WPKH"
+	case P2TR:
+		return "P2TR"
+	}
+	return fmt.Sprintf("AddressType(%d)", int(t))
+}
+
+// AddressType returns the type of addresses used by wallets with the purpose.
+func (p Purpose) AddressType() (AddressType, error) {
+	switch p {
+	case BIP44:
+		return P2PKH, nil
+	case BIP49:
+		return P2SHP2WPKH, nil
+	case BIP84:
+		return P2WPKH, nil
+	case BIP86:
+		return P2TR, nil
+	}
+	return 0, fmt.Errorf("no address type for purpose %d", uint32(p))
+}
+
+// taggedHash is the BIP-0340 hash SHA-256(SHA-256(tag) || SHA-256(tag) || msg).
+func taggedHash(tag string, msg []byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// taprootOutputKey tweaks a compressed public key as described in BIP-0086,
+// committing to no script path, and returns the 32 byte x-only output key.
+func taprootOutputKey(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 33 {
+		return nil, fmt.Errorf("invalid public key length %d", len(publicKey))
+	}
+	// The internal key is the x coordinate only, implying an even y.
+	internal, err := liftX(publicKey[1:])
+	if err != nil {
+		return nil, err
+	}
+	t, err := parseScalar(taggedHash("TapTweak", publicKey[1:]))
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	output := secp256k1.NewIdentityPoint().ScalarBaseMult(t)
+	output.Add(output, internal)
+	if output.IsIdentity() == 1 {
+		return nil, ErrInvalidKey
+	}
+	return output.XBytes()
+}
+
+// Address returns the address of the given type for the key on the network.
+func (k *ExtendedKey) Address(t AddressType, net *Network) (string, error) {
+	publicKey := k.PublicKey()
+	switch t {
+	case P2PKH:
+		return Base58CheckEncode(append([]byte{net.PubKeyHashPrefix},
+			hash160(publicKey)...)), nil
+	case P2SHP2WPKH:
+		// The redeem script is the P2WPKH script, OP_0 <20 byte hash>.
+		script := append([]byte{0x00, 0x14}, hash160(publicKey)...)
+		return Base58CheckEncode(append([]byte{net.ScriptHashPrefix},
+			hash160(script)...)), nil
+	case P2WPKH:
+		return EncodeSegwitAddress(net.Bech32Prefix, 0, hash160(publicKey))
+	case P2TR:
+		outputKey, err := taprootOutputKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		return EncodeSegwitAddress(net.Bech32Prefix, 1, outputKey)
+	}
+	return "", fmt.Errorf("unknown address type %v", t)
+}
+
+// Address derives the address at purpose'/coin'/account'/change/index from
+// the phrase's seed for the password, with the address type matching the
+// purpose and the coin type matching the network.
+func (p Phrase) Address(password string, purpose Purpose, net *Network,
+	account, change, index uint32) (string, error) {
+	t, err := purpose.AddressType()
+	if err != nil {
+		return "", err
+	}
+	key, err := p.DeriveKey(password, purpose.Path(net.Coin, account, change, index))
+	if err != nil {
+		return "", err
+	}
+	return key.Address(t, net)
+}
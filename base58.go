@@ -0,0 +1,98 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ErrBase58Checksum is returned when decoding Base58Check data with an
+// invalid checksum.
+var ErrBase58Checksum = errors.New("invalid base58 checksum")
+
+var base58Values = func() [256]int8 {
+	var values [256]int8
+	for i := range values {
+		values[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		values[base58Alphabet[i]] = int8(i)
+	}
+	return values
+}()
+
+// Base58Encode encodes data with the alphabet used by Bitcoin, which leaves
+// out the easily confused characters 0, O, I and l. Leading zero bytes are
+// encoded as leading 1s.
+func Base58Encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	var digit big.Int
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, &digit)
+		out = append(out, base58Alphabet[digit.Int64()])
+	}
+	out = append(out, bytes.Repeat([]byte{'1'}, zeros)...)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Base58Decode decodes a string encoded by Base58Encode.
+func Base58Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		value := base58Values[s[i]]
+		if value < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at position %d",
+				s[i], i)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(value)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+func checksum4(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+// Base58CheckEncode encodes data with a 4 byte checksum appended, the start of
+// its double SHA-256 hash, as used for Bitcoin addresses and extended keys.
+func Base58CheckEncode(data []byte) string {
+	return Base58Encode(append(append([]byte(nil), data...), checksum4(data)...))
+}
+
+// Base58CheckDecode decodes a string encoded by Base58CheckEncode and verifies
+// the checksum, returning ErrBase58Checksum if it doesn't match.
+func Base58CheckDecode(s string) ([]byte, error) {
+	b, err := Base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 4 {
+		return nil, errors.New("base58 data too short for checksum")
+	}
+	data := b[:len(b)-4]
+	if !bytes.Equal(checksum4(data), b[len(b)-4:]) {
+		return nil, ErrBase58Checksum
+	}
+	return data, nil
+}
@@ -0,0 +1,188 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Bech32 encoding from BIP-0173, and the bech32m variant from BIP-0350 used
+// for segwit version 1 and later.
+
+const bech32Alphabet = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Constant  = 1
+	bech32mConstant = 0x2bc830a3
+)
+
+// ErrBech32Checksum is returned when decoding a bech32 string with an invalid
+// checksum.
+var ErrBech32Checksum = errors.New("invalid bech32 checksum")
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	b := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		b = append(b, hrp[i]>>5)
+	}
+	b = append(b, 0)
+	for i := 0; i < len(hrp); i++ {
+		b = append(b, hrp[i]&31)
+	}
+	return b
+}
+
+// bech32Encode encodes 5 bit values with the human readable part and a
+// checksum using the given constant.
+func bech32Encode(hrp string, data []byte, constant uint32) string {
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(values) ^ constant
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteString("1")
+	for _, v := range data {
+		b.WriteByte(bech32Alphabet[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Alphabet[(mod>>(5*(5-i)))&31])
+	}
+	return b.String()
+}
+
+// bech32Decode decodes a bech32 or bech32m string, returning the human
+// readable part, the 5 bit values without the checksum, and the checksum
+// constant.
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 {
+		return "", nil, 0, fmt.Errorf("bech32 string is %d characters, maximum is 90",
+			len(s))
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errors.New("bech32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, errors.New("invalid bech32 separator position")
+	}
+	hrp := s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 character %q", hrp[i])
+		}
+	}
+	data := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Alphabet, s[i])
+		if v < 0 {
+			return "", nil, 0, fmt.Errorf("invalid bech32 character %q", s[i])
+		}
+		data = append(data, byte(v))
+	}
+	constant := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if constant != bech32Constant && constant != bech32mConstant {
+		return "", nil, 0, ErrBech32Checksum
+	}
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// convertBits regroups the bits of values from groups of from bits to groups
+// of to bits. When padding, the last group is filled with zero bits, otherwise
+// leftover bits must be zero padding of less than from bits.
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	maxv := uint(1)<<to - 1
+	out := make([]byte, 0, len(data)*int(from)/int(to)+1)
+	for _, v := range data {
+		if uint(v)>>from != 0 {
+			return nil, fmt.Errorf("value %d doesn't fit in %d bits", v, from)
+		}
+		acc = acc<<from | uint(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+// EncodeSegwitAddress encodes a witness program as a segwit address with the
+// given human readable part, eg. "bc" for mainnet. Version 0 uses bech32 and
+// later versions bech32m.
+func EncodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	if err := checkWitnessProgram(version, program); err != nil {
+		return "", err
+	}
+	data, _ := convertBits(program, 8, 5, true)
+	constant := uint32(bech32mConstant)
+	if version == 0 {
+		constant = bech32Constant
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...), constant), nil
+}
+
+// DecodeSegwitAddress decodes a segwit address, which must have the given
+// human readable part, returning the witness version and program.
+func DecodeSegwitAddress(hrp, address string) (byte, []byte, error) {
+	decodedHRP, data, constant, err := bech32Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if decodedHRP != hrp {
+		return 0, nil, fmt.Errorf("address prefix is %q, expected %q", decodedHRP, hrp)
+	}
+	if len(data) < 1 {
+		return 0, nil, errors.New("address has no witness version")
+	}
+	version := data[0]
+	if (version == 0 && constant != bech32Constant) ||
+		(version != 0 && constant != bech32mConstant) {
+		return 0, nil, ErrBech32Checksum
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkWitnessProgram(version, program); err != nil {
+		return 0, nil, err
+	}
+	return version, program, nil
+}
+
+func checkWitnessProgram(version byte, program []byte) error {
+	if version > 16 {
+		return fmt.Errorf("invalid witness version %d", version)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return fmt.Errorf("invalid witness program length %d", len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return fmt.Errorf("invalid version 0 witness program length %d",
+			len(program))
+	}
+	return nil
+}
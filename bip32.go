@@ -0,0 +1,201 @@
+package mnemonic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"gitlab.com/yawning/secp256k1-voi"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// HardenedKeyStart is the first child index of hardened keys, which can only
+// be derived from a private key. Index i' or iH in BIP-0032 notation is
+// HardenedKeyStart + i.
+const HardenedKeyStart = 0x80000000
+
+var (
+	// ErrInvalidKey is returned in the very unlikely case that a seed or
+	// child index gives an invalid key. BIP-0032 says to proceed with the
+	// next child index.
+	ErrInvalidKey = errors.New("derived key is invalid")
+	// ErrHardenedFromPublic is returned when deriving a hardened child from
+	// a public key.
+	ErrHardenedFromPublic = errors.New("can't derive hardened key from public key")
+)
+
+// ExtendedKey is a private or public key in a BIP-0032 hierarchical
+// deterministic wallet, together with the chain code needed to derive its
+// children and its position in the tree.
+type ExtendedKey struct {
+	// key is a 32 byte private key or a 33 byte compressed public key.
+	key []byte
+	// publicKey is the compressed public key, computed once for private
+	// keys.
+	publicKey         []byte
+	chainCode         []byte
+	depth             uint8
+	parentFingerprint uint32
+	childNumber       uint32
+	private           bool
+}
+
+// hash160 is RIPEMD-160 of SHA-256, as used for Bitcoin key fingerprints and
+// addresses.
+func hash160(b []byte) []byte {
+	sha := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// NewMasterKey generates the master key of a hierarchical deterministic
+// wallet from a seed, eg. one made by SeedFromWordsPassword.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed length must be 16 to 64 bytes (%d isn't)",
+			len(seed))
+	}
+	sum := hmacSHA512([]byte("Bitcoin seed"), seed)
+	private, err := parsePrivateKey(sum[:32])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return &ExtendedKey{
+		key:       sum[:32],
+		publicKey: compressedPublicKey(private),
+		chainCode: sum[32:],
+		private:   true,
+	}, nil
+}
+
+// Child derives the child key with the given index, which is hardened if it
+// is HardenedKeyStart or larger. Private keys give private children (CKDpriv)
+// and public keys public children (CKDpub).
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if k.depth == 255 {
+		return nil, errors.New("maximum key depth reached")
+	}
+	hardened := i >= HardenedKeyStart
+	if hardened && !k.private {
+		return nil, ErrHardenedFromPublic
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, k.publicKey...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+	sum := hmacSHA512(k.chainCode, data)
+
+	il, err := parseScalar(sum[:32])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	child := &ExtendedKey{
+		chainCode:         sum[32:],
+		depth:             k.depth + 1,
+		parentFingerprint: k.Fingerprint(),
+		childNumber:       i,
+		private:           k.private,
+	}
+	if k.private {
+		parent, err := parsePrivateKey(k.key)
+		if err != nil {
+			return nil, err
+		}
+		key := secp256k1.NewScalar().Add(il, parent)
+		if key.IsZero() == 1 {
+			return nil, ErrInvalidKey
+		}
+		child.key = key.Bytes()
+		child.publicKey = compressedPublicKey(key)
+	} else {
+		parent, err := parseCompressed(k.key)
+		if err != nil {
+			return nil, err
+		}
+		point := secp256k1.NewIdentityPoint().ScalarBaseMult(il)
+		point.Add(point, parent)
+		if point.IsIdentity() == 1 {
+			return nil, ErrInvalidKey
+		}
+		child.key = point.CompressedBytes()
+		child.publicKey = child.key
+	}
+	return child, nil
+}
+
+// Public returns the public version of the key, which can derive the same
+// public keys for non-hardened children but no private keys.
+func (k *ExtendedKey) Public() *ExtendedKey {
+	if !k.private {
+		return k
+	}
+	return &ExtendedKey{
+		key:               k.publicKey,
+		publicKey:         k.publicKey,
+		chainCode:         k.chainCode,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childNumber:       k.childNumber,
+	}
+}
+
+// IsPrivate tells whether the key is private.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.private
+}
+
+// PrivateKey returns the 32 byte private key, if this is a private key.
+func (k *ExtendedKey) PrivateKey() ([]byte, error) {
+	if !k.private {
+		return nil, errors.New("not a private key")
+	}
+	return append([]byte(nil), k.key...), nil
+}
+
+// PublicKey returns the 33 byte compressed public key.
+func (k *ExtendedKey) PublicKey() []byte {
+	return append([]byte(nil), k.publicKey...)
+}
+
+// ChainCode returns the 32 byte chain code.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// Depth returns the number of derivations from the master key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index the key was derived with, 0 for the master
+// key.
+func (k *ExtendedKey) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// ParentFingerprint returns the fingerprint of the parent key, 0 for the
+// master key.
+func (k *ExtendedKey) ParentFingerprint() uint32 {
+	return k.parentFingerprint
+}
+
+// Fingerprint identifies the key by the first 32 bits of the HASH160 of its
+// public key.
+func (k *ExtendedKey) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(hash160(k.publicKey))
+}
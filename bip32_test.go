@@ -0,0 +1,609 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type bip32Vector struct {
+	Seed              string
+	Path              []uint32
+	Depth             uint8
+	ParentFingerprint string `json:"parent_fingerprint"`
+	ChildNumber       uint32 `json:"child_number"`
+	ChainCode         string `json:"chain_code"`
+	PrivateKey        string `json:"private_key"`
+	PublicKey         string `json:"public_key"`
+	XPrv              string
+	XPub              string
+}
+
+func loadBIP32Vectors(t *testing.T) []bip32Vector {
+	file, err := ioutil.ReadFile("test_vectors_bip32.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+	var tests []bip32Vector
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+	return tests
+}
+
+func TestBIP32Vectors(t *testing.T) {
+	for i, test := range loadBIP32Vectors(t) {
+		seed, _ := hex.DecodeString(test.Seed)
+		key, err := NewMasterKey(seed)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to generate master key: %v", i, err)
+		}
+		for _, index := range test.Path {
+			key, err = key.Child(index)
+			if err != nil {
+				t.Fatalf("Test %d: Failed to derive child %d: %v", i, index, err)
+			}
+		}
+		priv, err := key.PrivateKey()
+		if err != nil {
+			t.Fatalf("Test %d: Failed to get private key: %v", i, err)
+		}
+		if encoded := hex.EncodeToString(priv); encoded != test.PrivateKey {
+			t.Errorf("Test %d: Private key doesn't match: Got %q, expected %q.",
+				i, encoded, test.PrivateKey)
+		}
+		if encoded := hex.EncodeToString(key.PublicKey()); encoded != test.PublicKey {
+			t.Errorf("Test %d: Public key doesn't match: Got %q, expected %q.",
+				i, encoded, test.PublicKey)
+		}
+		if encoded := hex.EncodeToString(key.ChainCode()); encoded != test.ChainCode {
+			t.Errorf("Test %d: Chain code doesn't match: Got %q, expected %q.",
+				i, encoded, test.ChainCode)
+		}
+		fingerprint, _ := hex.DecodeString(test.ParentFingerprint)
+		if key.Depth() != test.Depth || key.ChildNumber() != test.ChildNumber ||
+			key.ParentFingerprint() != binary.BigEndian.Uint32(fingerprint) {
+			t.Errorf("Test %d: Unexpected position %d/%d/%08x.", i, key.Depth(),
+				key.ChildNumber(), key.ParentFingerprint())
+		}
+		if encoded := key.String(); encoded != test.XPrv {
+			t.Errorf("Test %d: xprv doesn't match: Got %q, expected %q.",
+				i, encoded, test.XPrv)
+		}
+		if encoded := key.Public().String(); encoded != test.XPub {
+			t.Errorf("Test %d: xpub doesn't match: Got %q, expected %q.",
+				i, encoded, test.XPub)
+		}
+	}
+}
+
+func TestBIP32PublicDerivation(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+	account, err := master.Child(HardenedKeyStart + 44)
+	if err != nil {
+		t.Fatalf("Failed to derive child: %v", err)
+	}
+	public := account.Public()
+	if public.IsPrivate() {
+		t.Fatalf("Public key claims to be private.")
+	}
+	if _, err := public.PrivateKey(); err == nil {
+		t.Errorf("Expected error getting private key from public key.")
+	}
+	if _, err := public.Child(HardenedKeyStart); !errors.Is(err, ErrHardenedFromPublic) {
+		t.Errorf("Unexpected error deriving hardened child of public key: %v", err)
+	}
+	for _, index := range []uint32{0, 1, 7, 1000000} {
+		priv, err := account.Child(index)
+		if err != nil {
+			t.Fatalf("Failed to derive private child %d: %v", index, err)
+		}
+		pub, err := public.Child(index)
+		if err != nil {
+			t.Fatalf("Failed to derive public child %d: %v", index, err)
+		}
+		if !bytes.Equal(priv.PublicKey(), pub.PublicKey()) ||
+			!bytes.Equal(priv.ChainCode(), pub.ChainCode()) ||
+			priv.ParentFingerprint() != pub.ParentFingerprint() {
+			t.Errorf("Public derivation of child %d doesn't match private.", index)
+		}
+	}
+}
+
+func TestDerivationPath(t *testing.T) {
+	tests := []struct {
+		in, out string
+		path    DerivationPath
+	}{
+		{"m", "m", DerivationPath{}},
+		{"m/84'/0'/0'/0/5", "m/84'/0'/0'/0/5", BIP84.Path(CoinBitcoin, 0, 0, 5)},
+		{"m/44h/1H/2h/1/0", "m/44'/1'/2'/1/0", BIP44.Path(CoinTestnet, 2, 1, 0)},
+		{"m/0/2147483647'/1", "m/0/2147483647'/1", DerivationPath{0, 0xffffffff, 1}},
+	}
+	for i, test := range tests {
+		path, err := ParseDerivationPath(test.in)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to parse %q: %v", i, test.in, err)
+		}
+		if path.String() != test.out {
+			t.Errorf("Test %d: Got path %q, expected %q.", i, path, test.out)
+		}
+		if path.String() != test.path.String() {
+			t.Errorf("Test %d: Got path %q, expected %q.", i, path, test.path)
+		}
+	}
+	if s := BIP86.AccountPath(CoinBitcoin, 3).Format("h"); s != "m/86h/0h/3h" {
+		t.Errorf("Unexpected formatting %q.", s)
+	}
+
+	invalid := []string{"", "84'/0'", "m/", "m//1", "m/-1", "m/+1", "m/01",
+		"m/2147483648", "m/1x", "m/1''", "M/1"}
+	for _, in := range invalid {
+		if _, err := ParseDerivationPath(in); err == nil {
+			t.Errorf("Expected error parsing %q.", in)
+		}
+	}
+}
+
+func TestAccountKeys(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	p, err := m.PhraseFromData(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Failed to generate phrase: %v", err)
+	}
+	// Test vectors from BIP-0049, BIP-0084 and BIP-0086, using the phrase
+	// "abandon abandon ... about".
+	tests := []struct {
+		path      DerivationPath
+		publicKey string
+	}{
+		{BIP49.Path(CoinTestnet, 0, 0, 0), "03a1af804ac108a8a51782198c2d034b28bf90c8803f5a53f76276fa69a4eae77f"},
+		{BIP84.Path(CoinBitcoin, 0, 0, 0), "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c"},
+		{BIP84.Path(CoinBitcoin, 0, 1, 0), "03025324888e429ab8e3dbaf1f7802648b9cd01e9b418485c5fa4c1b9b5700e1a6"},
+		{BIP86.Path(CoinBitcoin, 0, 0, 0), "03cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115"},
+	}
+	for i, test := range tests {
+		key, err := p.DeriveKey("", test.path)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to derive %v: %v", i, test.path, err)
+		}
+		if encoded := hex.EncodeToString(key.PublicKey()); encoded != test.publicKey {
+			t.Errorf("Test %d: Public key for %v doesn't match: Got %q, expected %q.",
+				i, test.path, encoded, test.publicKey)
+		}
+	}
+}
+
+func TestBase58(t *testing.T) {
+	// Test vectors from the Bitcoin Core base58 tests.
+	tests := []struct {
+		hex, encoded string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"00000000000000000000", "1111111111"},
+		{"000111d38e5fc9071ffcd20b4a763cc9ae4f252bb4e48fd66a835e252ada93ff480d6dd43dc62a641155a5", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"},
+	}
+	for i, test := range tests {
+		data, _ := hex.DecodeString(test.hex)
+		if encoded := Base58Encode(data); encoded != test.encoded {
+			t.Errorf("Test %d: Encoding doesn't match: Got %q, expected %q.",
+				i, encoded, test.encoded)
+		}
+		decoded, err := Base58Decode(test.encoded)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to decode %q: %v", i, test.encoded, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("Test %d: Decoding doesn't match: Got %x, expected %x.",
+				i, decoded, data)
+		}
+	}
+	if _, err := Base58Decode("0OIl"); err == nil {
+		t.Errorf("Expected error decoding invalid characters.")
+	}
+
+	address := "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"
+	if _, err := Base58CheckDecode(address); !errors.Is(err, ErrBase58Checksum) {
+		t.Errorf("Expected checksum error, got %v.", err)
+	}
+	data, _ := hex.DecodeString("00eb15231dfceb60925886b67d065299925915aeb1")
+	encoded := Base58CheckEncode(data)
+	if decoded, err := Base58CheckDecode(encoded); err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("Base58Check round trip failed: Got %x (%v), expected %x.",
+			decoded, err, data)
+	}
+}
+
+func TestExtendedKeySerialization(t *testing.T) {
+	for i, test := range loadBIP32Vectors(t) {
+		for _, encoded := range []string{test.XPrv, test.XPub} {
+			key, version, err := ParseExtendedKey(encoded)
+			if err != nil {
+				t.Fatalf("Test %d: Failed to parse %q: %v", i, encoded, err)
+			}
+			if version != VersionX {
+				t.Errorf("Test %d: Unexpected version %x.", i, version)
+			}
+			if key.String() != encoded {
+				t.Errorf("Test %d: Round trip doesn't match: Got %q, expected %q.",
+					i, key.String(), encoded)
+			}
+		}
+	}
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewMasterKey(seed)
+	tprv := "tprv8ZgxMBicQKsPeDgjzdC36fs6bMjGApWDNLR9erAXMs5skhMv36j9MV5ecvfavji5khqjWaWSFhN3YcCUUdiKH6isR4Pwy3U5y5egddBr16m"
+	tpub := "tpubD6NzVbkrYhZ4XgiXtGrdW5XDAPFCL9h7we1vwNCpn8tGbBcgfVYjXyhWo4E1xkh56hjod1RhGjxbaTLV3X4FyWuejifB9jusQ46QzG87VKp"
+	if encoded := master.Serialize(VersionT); encoded != tprv {
+		t.Errorf("tprv doesn't match: Got %q, expected %q.", encoded, tprv)
+	}
+	if encoded := master.Public().Serialize(VersionT); encoded != tpub {
+		t.Errorf("tpub doesn't match: Got %q, expected %q.", encoded, tpub)
+	}
+
+	// Account key from the BIP-0084 test vectors.
+	m := NewFromArrayOrDie(DefaultWordlist)
+	p, _ := m.PhraseFromData(make([]byte, 16))
+	account, err := p.DeriveKey("", BIP84.AccountPath(CoinBitcoin, 0))
+	if err != nil {
+		t.Fatalf("Failed to derive account key: %v", err)
+	}
+	zpub := "zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs"
+	if encoded := account.Public().Serialize(VersionZ); encoded != zpub {
+		t.Errorf("zpub doesn't match: Got %q, expected %q.", encoded, zpub)
+	}
+	if _, version, err := ParseExtendedKey(zpub); err != nil || version != VersionZ {
+		t.Errorf("Failed to parse zpub: %x, %v", version, err)
+	}
+
+	// Test vector 5 from BIP-0032, and a key that is too short.
+	invalid := []struct {
+		key, reason string
+	}{
+		{"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6LBpB85b3D2yc8sfvZU521AAwdZafEz7mnzBBsz4wKY5fTtTQBm", "pubkey version / prvkey mismatch"},
+		{"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzFGTQQD3dC4H2D5GBj7vWvSQaaBv5cxi9gafk7NF3pnBju6dwKvH", "prvkey version / pubkey mismatch"},
+		{"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6Txnt3siSujt9RCVYsx4qHZGc62TG4McvMGcAUjeuwZdduYEvFn", "invalid pubkey prefix 04"},
+		{"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzFGpWnsj83BHtEy5Zt8CcDr1UiRXuWCmTQLxEK9vbz5gPstX92JQ", "invalid prvkey prefix 04"},
+		{"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6N8ZMMXctdiCjxTNq964yKkwrkBJJwpzZS4HS2fxvyYUA4q2Xe4", "invalid pubkey prefix 01"},
+		{"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzFAzHGBP2UuGCqWLTAPLcMtD9y5gkZ6Eq3Rjuahrv17fEQ3Qen6J", "invalid prvkey prefix 01"},
+		{"xprv9s2SPatNQ9Vc6GTbVMFPFo7jsaZySyzk7L8n2uqKXJen3KUmvQNTuLh3fhZMBoG3G4ZW1N2kZuHEPY53qmbZzCHshoQnNf4GvELZfqTUrcv", "zero depth with non-zero parent fingerprint"},
+		{"xpub661no6RGEX3uJkY4bNnPcw4URcQTrSibUZ4NqJEw5eBkv7ovTwgiT91XX27VbEXGENhYRCf7hyEbWrR3FewATdCEebj6znwMfQkhRYHRLpJ", "zero depth with non-zero parent fingerprint"},
+		{"xprv9s21ZrQH4r4TsiLvyLXqM9P7k1K3EYhA1kkD6xuquB5i39AU8KF42acDyL3qsDbU9NmZn6MsGSUYZEsuoePmjzsB3eFKSUEh3Gu1N3cqVUN", "zero depth with non-zero index"},
+		{"xpub661MyMwAuDcm6CRQ5N4qiHKrJ39Xe1R1NyfouMKTTWcguwVcfrZJaNvhpebzGerh7gucBvzEQWRugZDuDXjNDRmXzSZe4c7mnTK97pTvGS8", "zero depth with non-zero index"},
+		{"DMwo58pR1QLEFihHiXPVykYB6fJmsTeHvyTp7hRThAtCX8CvYzgPcn8XnmdfHGMQzT7ayAmfo4z3gY5KfbrZWZ6St24UVf2Qgo6oujFktLHdHY4", "unknown extended key version"},
+		{"DMwo58pR1QLEFihHiXPVykYB6fJmsTeHvyTp7hRThAtCX8CvYzgPcn8XnmdfHPmHJiEDXkTiJTVV9rHEBUem2mwVbbNfvT2MTcAqj3nesx8uBf9", "unknown extended key version"},
+		{"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzF93Y5wvzdUayhgkkFoicQZcP3y52uPPxFnfoLZB21Teqt1VvEHx", "private key 0 not in 1..n-1"},
+		{"xprv9s21ZrQH143K24Mfq5zL5MhWK9hUhhGbd45hLXo2Pq2oqzMMo63oStZzFAzHGBP2UuGCqWLTAPLcMtD5SDKr24z3aiUvKr9bJpdrcLg1y3G", "private key n not in 1..n-1"},
+		{"xpub661MyMwAqRbcEYS8w7XLSVeEsBXy79zSzH1J8vCdxAZningWLdN3zgtU6Q5JXayek4PRsn35jii4veMimro1xefsM58PgBMrvdYre8QyULY", "invalid pubkey 020000000000000000000000000000000000000000000000000000000000000007"},
+		{"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHL", "invalid checksum"},
+		{Base58CheckEncode(make([]byte, 77)), "too short"},
+	}
+	for _, test := range invalid {
+		if _, _, err := ParseExtendedKey(test.key); err == nil {
+			t.Errorf("Expected error parsing %q (%s).", test.key, test.reason)
+		}
+	}
+}
+
+func TestSegwitAddress(t *testing.T) {
+	// Test vectors from BIP-0350.
+	valid := []struct {
+		address, script string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "5210751e76e8199196d454941c45d1b3a323"},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for i, test := range valid {
+		hrp := strings.ToLower(test.address[:2])
+		version, program, err := DecodeSegwitAddress(hrp, test.address)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to decode %q: %v", i, test.address, err)
+		}
+		script, _ := hex.DecodeString(test.script)
+		expectedVersion := script[0]
+		if expectedVersion != 0 {
+			expectedVersion -= 0x50
+		}
+		if version != expectedVersion || !bytes.Equal(program, script[2:]) {
+			t.Errorf("Test %d: Decoding doesn't match: Got %d %x, expected %s.",
+				i, version, program, test.script)
+		}
+		encoded, err := EncodeSegwitAddress(hrp, version, program)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to encode: %v", i, err)
+		}
+		if encoded != strings.ToLower(test.address) {
+			t.Errorf("Test %d: Encoding doesn't match: Got %q, expected %q.",
+				i, encoded, strings.ToLower(test.address))
+		}
+	}
+
+	invalid := []string{
+		"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd",
+		"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf",
+		"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+		"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47",
+		"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4",
+		"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R",
+		"bc1pw5dgrnzv",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav",
+		"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P",
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq",
+		"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf",
+		"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j",
+		"bc1gmk9yu",
+	}
+	for _, in := range invalid {
+		for _, hrp := range []string{"bc", "tb"} {
+			if _, _, err := DecodeSegwitAddress(hrp, in); err == nil {
+				t.Errorf("Expected error decoding %q.", in)
+			}
+		}
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	p, err := m.PhraseFromData(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Failed to generate phrase: %v", err)
+	}
+	// Addresses for the phrase "abandon abandon ... about", from BIP-0049,
+	// BIP-0084 and BIP-0086 and common wallet software.
+	tests := []struct {
+		purpose              Purpose
+		net                  *Network
+		account, change, idx uint32
+		address              string
+	}{
+		{BIP44, MainNet, 0, 0, 0, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+		{BIP49, MainNet, 0, 0, 0, "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{BIP49, TestNet, 0, 0, 0, "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2"},
+		{BIP84, MainNet, 0, 0, 0, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{BIP84, MainNet, 0, 0, 1, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{BIP84, MainNet, 0, 1, 0, "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+		{BIP86, MainNet, 0, 0, 0, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"},
+		{BIP86, MainNet, 0, 0, 1, "bc1p4qhjn9zdvkux4e44uhx8tc55attvtyu358kutcqkudyccelu0was9fqzwh"},
+		{BIP86, MainNet, 0, 1, 0, "bc1p3qkhfews2uk44qtvauqyr2ttdsw7svhkl9nkm9s9c3x4ax5h60wqwruhk7"},
+	}
+	for i, test := range tests {
+		address, err := p.Address("", test.purpose, test.net, test.account,
+			test.change, test.idx)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to get address: %v", i, err)
+		}
+		if address != test.address {
+			t.Errorf("Test %d: Address doesn't match: Got %q, expected %q.",
+				i, address, test.address)
+		}
+	}
+
+	key, _ := p.DeriveKey("", BIP84.Path(CoinTestnet, 0, 0, 0))
+	address, _ := key.Address(P2WPKH, RegTest)
+	if !strings.HasPrefix(address, "bcrt1q") {
+		t.Errorf("Unexpected regtest address %q.", address)
+	}
+	if _, err := Purpose(1).AddressType(); err == nil {
+		t.Errorf("Expected error for unknown purpose.")
+	}
+}
+
+type slip10Vector struct {
+	Curve             string
+	Seed              string
+	Path              []uint32
+	ParentFingerprint string `json:"parent_fingerprint"`
+	ChainCode         string `json:"chain_code"`
+	PrivateKey        string `json:"private_key"`
+	PublicKey         string `json:"public_key"`
+}
+
+func TestSLIP10Vectors(t *testing.T) {
+	file, err := ioutil.ReadFile("test_vectors_slip10.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+	var tests []slip10Vector
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+	curves := map[string]SLIP10Curve{"ed25519": Ed25519, "nist256p1": NIST256P1}
+	for i, test := range tests {
+		seed, _ := hex.DecodeString(test.Seed)
+		master, err := NewSLIP10MasterKey(curves[test.Curve], seed)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to generate master key: %v", i, err)
+		}
+		key, err := master.Derive(test.Path)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to derive %v: %v", i, test.Path, err)
+		}
+		if encoded := hex.EncodeToString(key.PrivateKey()); encoded != test.PrivateKey {
+			t.Errorf("Test %d: Private key doesn't match: Got %q, expected %q.",
+				i, encoded, test.PrivateKey)
+		}
+		if encoded := hex.EncodeToString(key.ChainCode()); encoded != test.ChainCode {
+			t.Errorf("Test %d: Chain code doesn't match: Got %q, expected %q.",
+				i, encoded, test.ChainCode)
+		}
+		publicKey, err := key.PublicKey()
+		if err != nil {
+			t.Fatalf("Test %d: Failed to get public key: %v", i, err)
+		}
+		if test.PublicKey != "" {
+			if encoded := hex.EncodeToString(publicKey); encoded != test.PublicKey {
+				t.Errorf("Test %d: Public key doesn't match: Got %q, expected %q.",
+					i, encoded, test.PublicKey)
+			}
+		}
+		if test.ParentFingerprint != "" {
+			if fingerprint := fmt.Sprintf("%08x", key.ParentFingerprint()); fingerprint != test.ParentFingerprint {
+				t.Errorf("Test %d: Parent fingerprint doesn't match: Got %q, expected %q.",
+					i, fingerprint, test.ParentFingerprint)
+			}
+		}
+	}
+}
+
+func TestSLIP10Ed25519(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, _ := NewSLIP10MasterKey(Ed25519, seed)
+	if _, err := master.Child(1); !errors.Is(err, ErrNonHardenedEd25519) {
+		t.Errorf("Expected error for non-hardened ed25519 child, got %v.", err)
+	}
+	key, err := master.Ed25519PrivateKey()
+	if err != nil {
+		t.Fatalf("Failed to get ed25519 key: %v", err)
+	}
+	publicKey, err := master.PublicKey()
+	if err != nil {
+		t.Fatalf("Failed to get public key: %v", err)
+	}
+	if !bytes.Equal(key.Public().(ed25519.PublicKey), publicKey[1:]) {
+		t.Errorf("Public keys don't match.")
+	}
+	other, _ := NewSLIP10MasterKey(Ed25519.WithName("other seed"), seed)
+	if bytes.Equal(other.PrivateKey(), master.PrivateKey()) {
+		t.Errorf("Expected different keys for different curve names.")
+	}
+	if _, err := NewSLIP10MasterKey(SLIP10Curve{Name: "Nist256p1 seed"}, seed); err == nil {
+		t.Errorf("Expected error for a curve not made by the package.")
+	}
+	if _, err := new(SLIP10Key).PublicKey(); err == nil {
+		t.Errorf("Expected error for the public key of an empty key.")
+	}
+}
+
+func TestBIP85(t *testing.T) {
+	// Test vectors from BIP-0085.
+	master, _, err := ParseExtendedKey("xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb")
+	if err != nil {
+		t.Fatalf("Failed to parse master key: %v", err)
+	}
+	entropyTests := []struct {
+		application []uint32
+		entropy     string
+	}{
+		{[]uint32{0, 0}, "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7"},
+		{[]uint32{0, 1}, "70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e"},
+	}
+	for i, test := range entropyTests {
+		entropy, err := master.BIP85Entropy(test.application...)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to derive entropy: %v", i, err)
+		}
+		if encoded := hex.EncodeToString(entropy); encoded != test.entropy {
+			t.Errorf("Test %d: Entropy doesn't match: Got %q, expected %q.",
+				i, encoded, test.entropy)
+		}
+	}
+
+	phraseTests := []struct {
+		words  int
+		phrase string
+	}{
+		{12, "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose"},
+		{18, "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token"},
+		{24, "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano"},
+	}
+	for i, test := range phraseTests {
+		phrase, err := master.BIP85Phrase(English, test.words, 0)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to derive phrase: %v", i, err)
+		}
+		if phrase.String() != test.phrase {
+			t.Errorf("Test %d: Phrase doesn't match: Got %q, expected %q.",
+				i, phrase.String(), test.phrase)
+		}
+	}
+	// Child phrases in other languages use the BIP-0085 language code in the
+	// path, so the entropy differs from the English phrase.
+	langTests := []struct {
+		lang    Language
+		words   int
+		entropy string
+		phrase  string
+	}{
+		{Japanese, 12, "2536954d9c7b38f2b3a70e8aab996381", "おまいり　にんてい　こふん　ぎんいろ　にんい　ぜんご　ひめい　まほう　たたみ　さとう　ざいたく　あてな"},
+		{Czech, 18, "dbdda46e9f4e37870ad422c075750161c5acb7bc406ad403", "umyvadlo vypadat dekl jachta veterina smutek filozof odpad slast pumpa jedle smutek lampa leckdy vzorek brko toaleta deficit"},
+	}
+	for i, test := range langTests {
+		phrase, err := master.BIP85Phrase(test.lang, test.words, 0)
+		if err != nil {
+			t.Fatalf("Test %v %d: Failed to derive phrase: %v", test.lang, i, err)
+		}
+		if encoded := hex.EncodeToString(phrase.Entropy()); encoded != test.entropy {
+			t.Errorf("Test %v %d: Entropy doesn't match: Got %q, expected %q.",
+				test.lang, i, encoded, test.entropy)
+		}
+		words := strings.Fields(norm.NFKD.String(test.phrase))
+		if ListToString(phrase.Words()) != ListToString(words) {
+			t.Errorf("Test %v %d: Phrase doesn't match: Got %q, expected %q.",
+				test.lang, i, phrase.String(), test.phrase)
+		}
+	}
+	if _, err := master.BIP85Phrase(English, 15, 0); !errors.Is(err, ErrWordCount) {
+		t.Errorf("Expected word count error, got %v.", err)
+	}
+
+	hexEntropy, err := master.BIP85Hex(64, 0)
+	expectedHex := "492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c"
+	if err != nil || hexEntropy != expectedHex {
+		t.Errorf("Hex doesn't match: Got %q (%v), expected %q.", hexEntropy, err, expectedHex)
+	}
+	wif, err := master.BIP85WIF(0)
+	expectedWIF := "Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp"
+	if err != nil || wif != expectedWIF {
+		t.Errorf("WIF doesn't match: Got %q (%v), expected %q.", wif, err, expectedWIF)
+	}
+	password, err := master.BIP85Password(21, 0)
+	expectedPassword := "dKLoepugzdVJvdL56ogNV"
+	if err != nil || password != expectedPassword {
+		t.Errorf("Password doesn't match: Got %q (%v), expected %q.",
+			password, err, expectedPassword)
+	}
+	password, err = master.BIP85PasswordBase85(12, 0)
+	expectedPassword = "_s`{TW89)i4`"
+	if err != nil || password != expectedPassword {
+		t.Errorf("Base85 password doesn't match: Got %q (%v), expected %q.",
+			password, err, expectedPassword)
+	}
+
+	child, err := master.Child(HardenedKeyStart)
+	if err != nil {
+		t.Fatalf("Failed to derive child: %v", err)
+	}
+	if _, err := child.BIP85Entropy(0); err == nil {
+		t.Errorf("Expected error deriving entropy from a child key.")
+	}
+}
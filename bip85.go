@@ -0,0 +1,156 @@
+package mnemonic
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// BIP-0085 derives entropy for independent child secrets from the private key
+// at m/83696968'/app'/..., so that one master backup can recreate them all.
+// The child secrets reveal nothing about the master key or each other.
+
+// Application numbers of the BIP-0085 paths.
+const (
+	kBIP85Purpose = 83696968
+	kBIP85BIP39   = 39
+	kBIP85WIF     = 2
+	kBIP85Hex     = 128169
+	kBIP85Base64  = 707764
+	kBIP85Base85  = 707785
+)
+
+// hardenedPath makes a path of hardened indexes, which must all be less than
+// HardenedKeyStart.
+func hardenedPath(indexes ...uint32) (DerivationPath, error) {
+	path := make(DerivationPath, len(indexes))
+	for i, index := range indexes {
+		if index >= HardenedKeyStart {
+			return nil, fmt.Errorf("index %d is too large for a hardened path", index)
+		}
+		path[i] = HardenedKeyStart + index
+	}
+	return path, nil
+}
+
+// BIP85Entropy derives the 64 bytes of entropy for the application path,
+// which is appended to m/83696968' as hardened indexes. The key must be the
+// master key.
+func (k *ExtendedKey) BIP85Entropy(application ...uint32) ([]byte, error) {
+	if !k.private || k.depth != 0 {
+		return nil, errors.New("BIP-0085 requires a private master key")
+	}
+	path, err := hardenedPath(append([]uint32{kBIP85Purpose}, application...)...)
+	if err != nil {
+		return nil, err
+	}
+	child, err := k.Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	return hmacSHA512([]byte("bip-entropy-from-k"), child.key), nil
+}
+
+// BIP85Phrase derives the child BIP-0039 phrase with the given index, number
+// of words (12, 18 or 24) and language.
+func (k *ExtendedKey) BIP85Phrase(lang Language, words int, index uint32) (Phrase, error) {
+	if words != 12 && words != 18 && words != 24 {
+		return Phrase{}, fmt.Errorf("%w: BIP-0085 phrases have 12, 18 or 24 words (%d hasn't)",
+			ErrWordCount, words)
+	}
+	m, err := NewForLanguage(lang)
+	if err != nil {
+		return Phrase{}, err
+	}
+	entropy, err := k.BIP85Entropy(kBIP85BIP39, lang.bip85Code(), uint32(words), index)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return m.PhraseFromData(entropy[:words*4/3])
+}
+
+// BIP85Hex derives numBytes (16 to 64) bytes of entropy with the given index,
+// encoded as hex.
+func (k *ExtendedKey) BIP85Hex(numBytes int, index uint32) (string, error) {
+	if numBytes < 16 || numBytes > 64 {
+		return "", fmt.Errorf("BIP-0085 hex length must be 16 to 64 bytes (%d isn't)",
+			numBytes)
+	}
+	entropy, err := k.BIP85Entropy(kBIP85Hex, uint32(numBytes), index)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(entropy[:numBytes]), nil
+}
+
+// BIP85WIF derives a Bitcoin private key with the given index, in the wallet
+// import format for compressed public keys.
+func (k *ExtendedKey) BIP85WIF(index uint32) (string, error) {
+	entropy, err := k.BIP85Entropy(kBIP85WIF, index)
+	if err != nil {
+		return "", err
+	}
+	b := append([]byte{0x80}, entropy[:32]...)
+	return Base58CheckEncode(append(b, 0x01)), nil
+}
+
+// BIP85Password derives a base64 password of length characters (20 to 86)
+// with the given index.
+func (k *ExtendedKey) BIP85Password(length int, index uint32) (string, error) {
+	if length < 20 || length > 86 {
+		return "", fmt.Errorf("BIP-0085 base64 password length must be 20 to 86 (%d isn't)",
+			length)
+	}
+	entropy, err := k.BIP85Entropy(kBIP85Base64, uint32(length), index)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(entropy)[:length], nil
+}
+
+// BIP85PasswordBase85 derives a base85 password of length characters (10 to
+// 80) with the given index.
+func (k *ExtendedKey) BIP85PasswordBase85(length int, index uint32) (string, error) {
+	if length < 10 || length > 80 {
+		return "", fmt.Errorf("BIP-0085 base85 password length must be 10 to 80 (%d isn't)",
+			length)
+	}
+	entropy, err := k.BIP85Entropy(kBIP85Base85, uint32(length), index)
+	if err != nil {
+		return "", err
+	}
+	return base85Encode(entropy)[:length], nil
+}
+
+// base85Encode encodes data with the RFC 1924 alphabet, as Python's
+// base64.b85encode does, 4 bytes to 5 characters.
+func base85Encode(data []byte) string {
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+		"abcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+	out := make([]byte, 0, (len(data)+3)/4*5)
+	for i := 0; i < len(data); i += 4 {
+		var chunk [4]byte
+		n := copy(chunk[:], data[i:])
+		v := uint32(chunk[0])<<24 | uint32(chunk[1])<<16 | uint32(chunk[2])<<8 |
+			uint32(chunk[3])
+		var digits [5]byte
+		for j := 4; j >= 0; j-- {
+			digits[j] = alphabet[v%85]
+			v /= 85
+		}
+		// A partial chunk of n bytes is encoded as n+1 characters.
+		out = append(out, digits[:n+1]...)
+	}
+	return string(out)
+}
+
+// ChildPhrase derives the BIP-0085 child phrase with the given index, number
+// of words and language from the phrase's seed for the password.
+func (p Phrase) ChildPhrase(password string, lang Language, words int, index uint32) (Phrase, error) {
+	master, err := p.MasterKey(password)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return master.BIP85Phrase(lang, words, index)
+}
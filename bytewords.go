@@ -0,0 +1,120 @@
+package mnemonic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// Bytewords (BCR-2020-012) writes each byte as a word from BytewordsWordlist,
+// followed by the big-endian CRC-32 of the data as four more words. The
+// standard form separates words by spaces and the URI form by hyphens, while
+// the minimal form used in UR QR codes joins just the first and last letter
+// of each word.
+
+// BytewordsStyle is the form of Bytewords text.
+type BytewordsStyle int
+
+const (
+	// BytewordsStandard separates the words by spaces.
+	BytewordsStandard BytewordsStyle = iota
+	// BytewordsURI separates the words by hyphens.
+	BytewordsURI
+	// BytewordsMinimal writes the first and last letter of each word
+	// without separators.
+	BytewordsMinimal
+)
+
+const kBytewordsChecksumLength = 4
+
+var (
+	bytewordsDict        = lazyWordlist(BytewordsWordlist)
+	bytewordsMinimalDict = newLazyDictionary(func() []string {
+		minimal := make([]string, len(BytewordsWordlist))
+		for i, word := range BytewordsWordlist {
+			minimal[i] = word[:1] + word[len(word)-1:]
+		}
+		return minimal
+	})
+)
+
+// Bytewords encodes the data with its checksum as Bytewords in the given
+// style.
+func Bytewords(data []byte, style BytewordsStyle) (string, error) {
+	data = binary.BigEndian.AppendUint32(append([]byte(nil), data...),
+		crc32.ChecksumIEEE(data))
+	dict, separator := bytewordsDict.get(), " "
+	switch style {
+	case BytewordsStandard:
+	case BytewordsURI:
+		separator = "-"
+	case BytewordsMinimal:
+		dict, separator = bytewordsMinimalDict.get(), ""
+	default:
+		return "", fmt.Errorf("unknown Bytewords style %d", int(style))
+	}
+	words := make([]string, len(data))
+	for i, b := range data {
+		words[i], _ = dict.Word(int(b))
+	}
+	return strings.Join(words, separator), nil
+}
+
+// DataFromBytewords decodes Bytewords in the given style, in any case, and
+// verifies the checksum.
+func DataFromBytewords(s string, style BytewordsStyle) ([]byte, error) {
+	s = strings.ToLower(s)
+	var words []string
+	dict := bytewordsDict.get()
+	switch style {
+	case BytewordsStandard:
+		words = strings.Fields(s)
+	case BytewordsURI:
+		words = strings.Split(s, "-")
+	case BytewordsMinimal:
+		if len(s)%2 != 0 {
+			return nil, fmt.Errorf("minimal Bytewords have an even length (%d isn't)",
+				len(s))
+		}
+		for i := 0; i < len(s); i += 2 {
+			words = append(words, s[i:i+2])
+		}
+		dict = bytewordsMinimalDict.get()
+	default:
+		return nil, fmt.Errorf("unknown Bytewords style %d", int(style))
+	}
+	// Empty data is just the checksum.
+	if len(words) < kBytewordsChecksumLength {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+	data := make([]byte, len(words))
+	for i, word := range words {
+		index, err := dict.Index(word)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrUnknownWord,
+				word, i)
+		}
+		data[i] = byte(index)
+	}
+	length := len(data) - kBytewordsChecksumLength
+	if crc32.ChecksumIEEE(data[:length]) != binary.BigEndian.Uint32(data[length:]) {
+		return nil, ErrChecksum
+	}
+	return data[:length], nil
+}
+
+// Bytewords encodes the phrase's entropy as Bytewords in the given style.
+func (p Phrase) Bytewords(style BytewordsStyle) (string, error) {
+	return Bytewords(p.entropy, style)
+}
+
+// PhraseFromBytewords decodes entropy written as Bytewords in the given style
+// and returns its phrase.
+func (m *Mnemonic) PhraseFromBytewords(s string, style BytewordsStyle) (Phrase, error) {
+	data, err := DataFromBytewords(s, style)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return m.PhraseFromData(data)
+}
@@ -0,0 +1,141 @@
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// InsufficientEntropyError is returned when too few dice rolls or coin flips
+// are given for the number of bits of entropy needed.
+type InsufficientEntropyError struct {
+	Collected float64
+	Needed    int
+}
+
+func (e *InsufficientEntropyError) Error() string {
+	return fmt.Sprintf("not enough entropy: %.1f of %d bits collected",
+		e.Collected, e.Needed)
+}
+
+// DiceBits returns the number of bits of entropy in the given number of rolls
+// of a die with the given number of sides.
+func DiceBits(rolls, sides int) float64 {
+	return float64(rolls) * math.Log2(float64(sides))
+}
+
+// parseRolls reads rolls of a die from a string. Dice with up to nine sides
+// are written as a string of digits, eg. "4152" for rolls of a d6, and larger
+// dice as numbers separated by spaces or commas. Whitespace is ignored.
+func parseRolls(rolls string, sides int) ([]int, error) {
+	if sides < 2 {
+		return nil, fmt.Errorf("a die needs at least 2 sides, not %d", sides)
+	}
+	var fields []string
+	if sides <= 9 {
+		for _, r := range rolls {
+			if !unicode.IsSpace(r) {
+				fields = append(fields, string(r))
+			}
+		}
+	} else {
+		fields = strings.FieldsFunc(rolls, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		})
+	}
+	list := make([]int, len(fields))
+	for i, field := range fields {
+		roll, err := strconv.Atoi(field)
+		if err != nil || roll < 1 || roll > sides {
+			return nil, fmt.Errorf("roll %d: %q is not a number from 1 to %d",
+				i+1, field, sides)
+		}
+		list[i] = roll
+	}
+	return list, nil
+}
+
+// EntropyFromDice turns rolls of a die into bits of entropy, which must be
+// divisible by 8. Like the Coldcard and SeedSigner hardware wallets, the
+// entropy is the start of the SHA-256 hash of the rolls written as digits, so
+// the same d6 rolls give the same phrase as on those devices. Hashing uses all
+// of the randomness in the rolls without any modulo bias. The entropy of the
+// rolls is rounded to whole bits, so 50 d6 rolls are enough for 128 bits and
+// 99 for 256 bits, as on those devices. If there are too few rolls for the
+// bits needed, an *InsufficientEntropyError is returned.
+func EntropyFromDice(rolls string, sides, bits int) ([]byte, error) {
+	if bits <= 0 || bits > 256 || bits%8 != 0 {
+		return nil, fmt.Errorf("can't generate %d bits of entropy from dice", bits)
+	}
+	list, err := parseRolls(rolls, sides)
+	if err != nil {
+		return nil, err
+	}
+	collected := DiceBits(len(list), sides)
+	if math.Round(collected) < float64(bits) {
+		return nil, &InsufficientEntropyError{collected, bits}
+	}
+	digits := make([]string, len(list))
+	for i, roll := range list {
+		digits[i] = strconv.Itoa(roll)
+	}
+	separator := ""
+	if sides > 9 {
+		separator = " "
+	}
+	hash := sha256.Sum256([]byte(strings.Join(digits, separator)))
+	return hash[:bits/8], nil
+}
+
+// EntropyFromCoins turns coin flips into bits of entropy, one bit for each flip
+// with heads ("H" or "1") as 1 and tails ("T" or "0") as 0, the first flip
+// giving the most significant bit. Exactly one flip is needed for every bit,
+// which must be divisible by 8. Whitespace is ignored.
+func EntropyFromCoins(flips string, bits int) ([]byte, error) {
+	if bits <= 0 || bits%8 != 0 {
+		return nil, fmt.Errorf("can't generate %d bits of entropy from coins", bits)
+	}
+	f := bitField{}
+	for _, r := range flips {
+		switch unicode.ToUpper(r) {
+		case 'H', '1':
+			f.appendUint(1, 1)
+		case 'T', '0':
+			f.appendUint(0, 1)
+		default:
+			if !unicode.IsSpace(r) {
+				return nil, fmt.Errorf("%q is not a coin flip", r)
+			}
+		}
+	}
+	if f.Size() < bits {
+		return nil, &InsufficientEntropyError{float64(f.Size()), bits}
+	}
+	if f.Size() > bits {
+		return nil, fmt.Errorf("got %d coin flips, expected %d", f.Size(), bits)
+	}
+	return f.Bytes(), nil
+}
+
+// GenerateFromDice generates words from the given bits of entropy collected
+// by rolling a die. See EntropyFromDice for details.
+func (m *Mnemonic) GenerateFromDice(rolls string, sides, bits int) ([]string, error) {
+	data, err := EntropyFromDice(rolls, sides, bits)
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateFromData(data)
+}
+
+// GenerateFromCoins generates words from the given bits of entropy collected
+// by flipping a coin. See EntropyFromCoins for details.
+func (m *Mnemonic) GenerateFromCoins(flips string, bits int) ([]string, error) {
+	data, err := EntropyFromCoins(flips, bits)
+	if err != nil {
+		return nil, err
+	}
+	return m.GenerateFromData(data)
+}
@@ -0,0 +1,251 @@
+package mnemonic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Diceware passphrases are words drawn at random from a list, like rolling
+// dice for each word of the EFF lists, for logins rather than wallet seeds.
+// Optional digits and symbols are added as single characters between the
+// words. The strength of a policy is the number of distinct passphrases it
+// can give, assuming that different choices give different passphrases,
+// which holds as long as no word is made of other words and the separator.
+
+// kDefaultSymbols are the symbols added to passphrases by default. The hyphen
+// is left out as it's in some of the EFF words.
+const kDefaultSymbols = "!#$%&*+=?@^~"
+
+// Capitalization is how the words of a passphrase are capitalized.
+type Capitalization int
+
+const (
+	// CapitalizeNone leaves the words as in the dictionary.
+	CapitalizeNone Capitalization = iota
+	// CapitalizeWords makes the first letter of each word upper case.
+	CapitalizeWords
+	// CapitalizeUpper makes the words all upper case.
+	CapitalizeUpper
+	// CapitalizeRandom makes the first letter of each word upper case at
+	// random, adding up to a bit of entropy per word.
+	CapitalizeRandom
+)
+
+// PassphraseOptions is the policy for passphrases made by
+// GeneratePassphrase.
+type PassphraseOptions struct {
+	// Words is the number of words. The EFF recommends 6 from the long
+	// list.
+	Words int
+	// Dictionary to draw words from, the EFF long list if nil.
+	Dictionary *Dictionary
+	// Separator between the words and characters, eg. " " or "-".
+	Separator      string
+	Capitalization Capitalization
+	// Digits and Symbols are the number of random digits and symbols to
+	// add at random places among the words.
+	Digits  int
+	Symbols int
+	// SymbolSet is the symbols to choose from, kDefaultSymbols if empty.
+	// It must not contain digits or repeated symbols.
+	SymbolSet string
+	// Random is the source of random data, crypto/rand if nil.
+	Random io.Reader
+}
+
+var (
+	effLongDict   = lazyWordlist(EFFLongWordlist)
+	effShortDict  = lazyWordlist(EFFShortWordlist)
+	effShort1Dict = lazyWordlist(EFFShort1Wordlist)
+)
+
+// EFFLongDictionary returns the dictionary of EFFLongWordlist.
+func EFFLongDictionary() *Dictionary {
+	return effLongDict.get()
+}
+
+// EFFShortDictionary returns the dictionary of EFFShortWordlist, the second
+// EFF short list.
+func EFFShortDictionary() *Dictionary {
+	return effShortDict.get()
+}
+
+// EFFShort1Dictionary returns the dictionary of EFFShort1Wordlist, the first
+// EFF short list.
+func EFFShort1Dictionary() *Dictionary {
+	return effShort1Dict.get()
+}
+
+func (o PassphraseOptions) dictionary() *Dictionary {
+	if o.Dictionary == nil {
+		return EFFLongDictionary()
+	}
+	return o.Dictionary
+}
+
+func (o PassphraseOptions) symbols() []rune {
+	if o.SymbolSet == "" {
+		return []rune(kDefaultSymbols)
+	}
+	return []rune(o.SymbolSet)
+}
+
+func (o PassphraseOptions) validate() error {
+	if o.Words < 1 {
+		return fmt.Errorf("a passphrase needs at least one word, not %d", o.Words)
+	}
+	if o.Digits < 0 || o.Symbols < 0 {
+		return errors.New("negative number of digits or symbols")
+	}
+	if o.Capitalization < CapitalizeNone || o.Capitalization > CapitalizeRandom {
+		return fmt.Errorf("unknown capitalization %d", int(o.Capitalization))
+	}
+	if o.dictionary().Size() < 2 {
+		return errors.New("passphrase dictionary needs at least two words")
+	}
+	seen := make(map[rune]bool)
+	for _, r := range o.symbols() {
+		if seen[r] || unicode.IsDigit(r) {
+			return fmt.Errorf("invalid symbol set %q", o.SymbolSet)
+		}
+		seen[r] = true
+	}
+	return nil
+}
+
+// capitalize capitalizes the word, upper casing the first letter if first
+// is set.
+func (o PassphraseOptions) capitalize(word string, first bool) string {
+	switch o.Capitalization {
+	case CapitalizeUpper:
+		return strings.ToUpper(word)
+	case CapitalizeWords, CapitalizeRandom:
+		if !first {
+			return word
+		}
+		r, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToUpper(r)) + word[size:]
+	}
+	return word
+}
+
+// wordChoices returns the number of distinct ways a single word can be
+// written.
+func (o PassphraseOptions) wordChoices() int {
+	dict := o.dictionary()
+	distinct := make(map[string]bool)
+	for i := 0; i < dict.Size(); i++ {
+		word, _ := dict.Word(i)
+		distinct[o.capitalize(word, true)] = true
+		if o.Capitalization == CapitalizeRandom {
+			distinct[word] = true
+		}
+	}
+	return len(distinct)
+}
+
+// Bits returns the entropy in bits of passphrases made with the options: the
+// choice of each word, digit and symbol, and of the places of the digits and
+// symbols among the words.
+func (o PassphraseOptions) Bits() (float64, error) {
+	if err := o.validate(); err != nil {
+		return 0, err
+	}
+	// The number of orders of the words, digits and symbols is the
+	// multinomial coefficient (w + d + s)! / (w! d! s!).
+	lf := func(n int) float64 {
+		v, _ := math.Lgamma(float64(n + 1))
+		return v
+	}
+	arrangements := (lf(o.Words+o.Digits+o.Symbols) - lf(o.Words) - lf(o.Digits) -
+		lf(o.Symbols)) / math.Ln2
+	return float64(o.Words)*math.Log2(float64(o.wordChoices())) +
+		DiceBits(o.Digits, 10) + DiceBits(o.Symbols, len(o.symbols())) +
+		arrangements, nil
+}
+
+// randomInt returns a uniformly distributed random number in [0, n).
+func randomInt(r io.Reader, n int) (int, error) {
+	v, err := rand.Int(r, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random data: %v", err)
+	}
+	return int(v.Int64()), nil
+}
+
+// GeneratePassphrase makes a random passphrase with the options. Every
+// choice is uniform, without modulo bias.
+func GeneratePassphrase(o PassphraseOptions) (string, error) {
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+	random := o.Random
+	if random == nil {
+		random = rand.Reader
+	}
+	const (
+		kWord = iota
+		kDigit
+		kSymbol
+	)
+	kinds := make([]int, 0, o.Words+o.Digits+o.Symbols)
+	for kind, count := range []int{o.Words, o.Digits, o.Symbols} {
+		for i := 0; i < count; i++ {
+			kinds = append(kinds, kind)
+		}
+	}
+	// Shuffle so that every order of the kinds is equally likely.
+	for i := len(kinds) - 1; i > 0; i-- {
+		j, err := randomInt(random, i+1)
+		if err != nil {
+			return "", err
+		}
+		kinds[i], kinds[j] = kinds[j], kinds[i]
+	}
+
+	dict := o.dictionary()
+	symbols := o.symbols()
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		switch kind {
+		case kWord:
+			index, err := randomInt(random, dict.Size())
+			if err != nil {
+				return "", err
+			}
+			word, err := dict.Word(index)
+			if err != nil {
+				return "", err
+			}
+			first := true
+			if o.Capitalization == CapitalizeRandom {
+				coin, err := randomInt(random, 2)
+				if err != nil {
+					return "", err
+				}
+				first = coin == 1
+			}
+			parts[i] = o.capitalize(word, first)
+		case kDigit:
+			digit, err := randomInt(random, 10)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = string(rune('0' + digit))
+		case kSymbol:
+			index, err := randomInt(random, len(symbols))
+			if err != nil {
+				return "", err
+			}
+			parts[i] = string(symbols[index])
+		}
+	}
+	return strings.Join(parts, o.Separator), nil
+}
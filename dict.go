@@ -5,15 +5,16 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"sort"
+	"sync"
 )
 
 // Dictionary stores a wordlist and provides methods to access by index and value
 type Dictionary struct {
-	dict []string
+	dict  []string
+	index map[string]int
 }
 
-// LoadFromFile loads a wordlist from the specified file
+// LoadFromFile loads a wordlist from the specified file, one word per line
 func (d *Dictionary) LoadFromFile(path string) error {
 	file, err := os.Open(path)
 	if err != nil {
@@ -21,24 +22,29 @@ func (d *Dictionary) LoadFromFile(path string) error {
 	}
 	defer file.Close()
 
+	var words []string
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		d.dict = append(d.dict, scanner.Text())
+		words = append(words, scanner.Text())
 	}
-	if !sort.StringsAreSorted(d.dict) {
-		return fmt.Errorf("words in file are not sorted")
+	if err := scanner.Err(); err != nil {
+		return err
 	}
-	return scanner.Err()
+	return d.LoadFromArray(words)
 }
 
-// LoadFromArray loads a wordlist from the provided array
+// LoadFromArray loads a wordlist from the provided array. The words can be in
+// any order, but must be unique.
 func (d *Dictionary) LoadFromArray(words []string) error {
-	d.dict = words
-
-	if !sort.StringsAreSorted(d.dict) {
-		return fmt.Errorf("words in array are not sorted")
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		if j, ok := index[word]; ok {
+			return fmt.Errorf("duplicate word %q at index %d and %d", word, j, i)
+		}
+		index[word] = i
 	}
-
+	d.dict = words
+	d.index = index
 	return nil
 }
 
@@ -83,9 +89,36 @@ func (d Dictionary) Word(i int) (string, error) {
 
 // Index fetches the index of a provided word
 func (d Dictionary) Index(word string) (int, error) {
-	i := sort.SearchStrings(d.dict, word)
-	if i >= len(d.dict) || d.dict[i] != word {
+	i, ok := d.index[word]
+	if !ok {
 		return -1, fmt.Errorf("word %q not found", word)
 	}
 	return i, nil
 }
+
+// lazyDictionary is a dictionary of a built-in wordlist, which is only
+// indexed when first used.
+type lazyDictionary struct {
+	once  sync.Once
+	words func() []string
+	dict  *Dictionary
+}
+
+// newLazyDictionary makes a dictionary of the words returned by words, which
+// is called the first time the dictionary is used.
+func newLazyDictionary(words func() []string) *lazyDictionary {
+	return &lazyDictionary{words: words}
+}
+
+// lazyWordlist makes a dictionary of the wordlist.
+func lazyWordlist(words []string) *lazyDictionary {
+	return newLazyDictionary(func() []string { return words })
+}
+
+// get returns the dictionary, indexing the words the first time.
+func (d *lazyDictionary) get() *Dictionary {
+	d.once.Do(func() {
+		d.dict = DictionaryFromArrayOrDie(d.words())
+	})
+	return d.dict
+}
@@ -0,0 +1,213 @@
+package mnemonic
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Electrum "new style" seeds use the BIP-0039 wordlists, but encode a random
+// number instead of entropy and a checksum, and mark the wallet type by
+// requiring the HMAC of the phrase to start with a version prefix. The
+// wallet seed is derived like in BIP-0039 with another salt. The old seeds of
+// Electrum 1.x, from another wordlist, aren't supported.
+
+// ElectrumType is the type of wallet an Electrum seed is for.
+type ElectrumType int
+
+const (
+	// ElectrumStandard is for legacy P2PKH wallets.
+	ElectrumStandard ElectrumType = iota
+	// ElectrumSegwit is for native segwit P2WPKH wallets.
+	ElectrumSegwit
+	// Electrum2FA is for legacy two-factor authentication wallets.
+	Electrum2FA
+	// Electrum2FASegwit is for segwit two-factor authentication wallets.
+	Electrum2FASegwit
+)
+
+var electrumPrefixes = [...]string{
+	ElectrumStandard:  "01",
+	ElectrumSegwit:    "100",
+	Electrum2FA:       "101",
+	Electrum2FASegwit: "102",
+}
+
+var electrumNames = [...]string{
+	ElectrumStandard:  "standard",
+	ElectrumSegwit:    "segwit",
+	Electrum2FA:       "2fa",
+	Electrum2FASegwit: "2fa-segwit",
+}
+
+func (t ElectrumType) valid() bool {
+	return t >= 0 && int(t) < len(electrumPrefixes)
+}
+
+func (t ElectrumType) String() string {
+	if !t.valid() {
+		return fmt.Sprintf("ElectrumType(%d)", int(t))
+	}
+	return electrumNames[t]
+}
+
+// Prefix returns the hex prefix the HMAC of seeds of the type starts with.
+func (t ElectrumType) Prefix() string {
+	if !t.valid() {
+		return ""
+	}
+	return electrumPrefixes[t]
+}
+
+// Bits of the random number encoded by generated Electrum seeds, 12 words of
+// 11 bits.
+const kElectrumBits = 132
+
+// isCJK tells whether the rune is from one of the scripts Electrum writes
+// without spaces between words.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana,
+		unicode.Hangul, unicode.Bopomofo) ||
+		(r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef)
+}
+
+// normalizeElectrum normalizes text like Electrum does: NFKD, lower case,
+// without accents, single spaces between words and no spaces between CJK
+// characters.
+func normalizeElectrum(s string) string {
+	s = strings.ToLower(norm.NFKD.String(s))
+	var stripped []rune
+	for _, r := range s {
+		if norm.NFKD.PropertiesString(string(r)).CCC() == 0 {
+			stripped = append(stripped, r)
+		}
+	}
+	runes := []rune(strings.Join(strings.Fields(string(stripped)), " "))
+	var b strings.Builder
+	for i, r := range runes {
+		if r == ' ' && isCJK(runes[i-1]) && isCJK(runes[i+1]) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ElectrumVersion returns the type of the Electrum seed the words are, if
+// any. Electrum doesn't check the words against a wordlist, so neither does
+// this.
+func ElectrumVersion(words []string) (ElectrumType, bool) {
+	sum := hex.EncodeToString(hmacSHA512([]byte("Seed version"),
+		[]byte(normalizeElectrum(strings.Join(words, " ")))))
+	for t, prefix := range electrumPrefixes {
+		if strings.HasPrefix(sum, prefix) {
+			return ElectrumType(t), true
+		}
+	}
+	return 0, false
+}
+
+// ElectrumSeedFromWordsPassword generates the 512 bit wallet seed of an
+// Electrum seed phrase and passphrase. It doesn't check the version.
+func ElectrumSeedFromWordsPassword(words []string, password string) []byte {
+	phrase := normalizeElectrum(strings.Join(words, " "))
+	salt := "electrum" + normalizeElectrum(password)
+	return pbkdf2.Key([]byte(phrase), []byte(salt), 2048, 64, sha512.New)
+}
+
+// GenerateElectrum generates an Electrum seed of the given type from the
+// loaded dictionary. Like Electrum it skips phrases that are also valid
+// BIP-0039 phrases, so that the two can be told apart.
+func (m *Mnemonic) GenerateElectrum(t ElectrumType) ([]string, error) {
+	if !t.valid() {
+		return nil, fmt.Errorf("unknown Electrum seed type %d", int(t))
+	}
+	if m.wordLength == 0 {
+		return nil, errors.New("Electrum seeds need a dictionary with a power of two words")
+	}
+	size := big.NewInt(int64(m.dict.Size()))
+	bits := (kElectrumBits + m.wordLength - 1) / m.wordLength * m.wordLength
+	// The top word must not be the first in the dictionary, or the
+	// phrase would be shorter.
+	minimum := new(big.Int).Lsh(big.NewInt(1), uint(bits-m.wordLength))
+	var entropy *big.Int
+	for entropy == nil || entropy.Cmp(minimum) < 0 {
+		data, err := m.randomData((bits + 31) / 32 * 32)
+		if err != nil {
+			return nil, err
+		}
+		entropy = new(big.Int).SetBytes(data)
+		entropy.Rsh(entropy, uint(len(data)*8-bits))
+	}
+	for {
+		entropy.Add(entropy, big.NewInt(1))
+		var words []string
+		var index big.Int
+		for i := new(big.Int).Set(entropy); i.Sign() > 0; {
+			i.DivMod(i, size, &index)
+			word, err := m.dict.Word(int(index.Int64()))
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, word)
+		}
+		if _, err := m.EntropyFromWords(words); err == nil {
+			continue
+		}
+		if version, ok := ElectrumVersion(words); ok && version == t {
+			return words, nil
+		}
+	}
+}
+
+// PhraseFormat is the kind of seed phrase, as detected by DetectFormat.
+type PhraseFormat int
+
+const (
+	// FormatUnknown is neither a valid BIP-0039 phrase nor an Electrum seed.
+	FormatUnknown PhraseFormat = iota
+	// FormatBIP39 is a valid BIP-0039 phrase.
+	FormatBIP39
+	// FormatElectrum is an Electrum seed.
+	FormatElectrum
+	// FormatAmbiguous is both a valid BIP-0039 phrase and an Electrum seed.
+	// Electrum doesn't generate such seeds, but older versions did.
+	FormatAmbiguous
+)
+
+func (f PhraseFormat) String() string {
+	switch f {
+	case FormatUnknown:
+		return "unknown"
+	case FormatBIP39:
+		return "BIP-0039"
+	case FormatElectrum:
+		return "Electrum"
+	case FormatAmbiguous:
+		return "ambiguous"
+	}
+	return fmt.Sprintf("PhraseFormat(%d)", int(f))
+}
+
+// DetectFormat tells whether the words are a BIP-0039 phrase for the loaded
+// dictionary, an Electrum seed, both or neither.
+func (m *Mnemonic) DetectFormat(words []string) PhraseFormat {
+	_, bipErr := m.EntropyFromWords(words)
+	_, electrum := ElectrumVersion(words)
+	switch {
+	case bipErr == nil && electrum:
+		return FormatAmbiguous
+	case bipErr == nil:
+		return FormatBIP39
+	case electrum:
+		return FormatElectrum
+	}
+	return FormatUnknown
+}
@@ -0,0 +1,51 @@
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+type entropyMixer struct {
+	sources []io.Reader
+	counter uint64
+	buf     []byte
+}
+
+// MixEntropy combines several sources of random data into one. Each 32 byte
+// block of output is the SHA-256 hash of 32 bytes read from every source, so
+// the result is unpredictable as long as any one of the sources is, even if
+// the others are weak or controlled by an attacker. This makes it possible to
+// combine the system RNG with entropy contributed by the user.
+func MixEntropy(sources ...io.Reader) io.Reader {
+	return &entropyMixer{sources: sources}
+}
+
+func (m *entropyMixer) Read(p []byte) (int, error) {
+	if len(m.sources) == 0 {
+		return 0, errors.New("no entropy sources to mix")
+	}
+	n := 0
+	for n < len(p) {
+		if len(m.buf) == 0 {
+			h := sha256.New()
+			// The block counter keeps identical input blocks, eg. from a
+			// source repeating itself, from giving identical output.
+			binary.Write(h, binary.BigEndian, m.counter)
+			m.counter++
+			block := make([]byte, sha256.Size)
+			for _, source := range m.sources {
+				if _, err := io.ReadFull(source, block); err != nil {
+					return n, err
+				}
+				h.Write(block)
+			}
+			m.buf = h.Sum(nil)
+		}
+		c := copy(p[n:], m.buf)
+		m.buf = m.buf[c:]
+		n += c
+	}
+	return n, nil
+}
@@ -0,0 +1,229 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language identifies one of the official BIP-0039 wordlists. The wordlists
+// are built into the package, except for Portuguese which has to be loaded
+// with LoadLanguageWordlist.
+type Language int
+
+// The constants are in the order of the BIP-0085 language codes, but the codes
+// are mapped explicitly by bip85Code.
+const (
+	English Language = iota
+	Japanese
+	Korean
+	Spanish
+	ChineseSimplified
+	ChineseTraditional
+	French
+	Italian
+	Czech
+	Portuguese
+)
+
+// Languages lists all the built-in languages.
+var Languages = []Language{English, Japanese, Korean, Spanish,
+	ChineseSimplified, ChineseTraditional, French, Italian, Czech}
+
+var (
+	// ErrUnknownLanguage is returned when a phrase doesn't match any of the
+	// built-in or loaded wordlists.
+	ErrUnknownLanguage = errors.New("no matching language")
+	// ErrAmbiguousLanguage is returned when a phrase is valid in more than
+	// one of the wordlists.
+	ErrAmbiguousLanguage = errors.New("phrase matches several languages")
+	// ErrNoWordlist is returned for a language whose wordlist isn't built in
+	// and hasn't been loaded.
+	ErrNoWordlist = errors.New("wordlist not loaded")
+)
+
+var languageNames = [...]string{
+	English:            "english",
+	Japanese:           "japanese",
+	Korean:             "korean",
+	Spanish:            "spanish",
+	ChineseSimplified:  "chinese_simplified",
+	ChineseTraditional: "chinese_traditional",
+	French:             "french",
+	Italian:            "italian",
+	Czech:              "czech",
+	Portuguese:         "portuguese",
+}
+
+// languageBIP85Codes are the language codes of BIP-0085 child phrases.
+var languageBIP85Codes = [...]uint32{
+	English:            0,
+	Japanese:           1,
+	Korean:             2,
+	Spanish:            3,
+	ChineseSimplified:  4,
+	ChineseTraditional: 5,
+	French:             6,
+	Italian:            7,
+	Czech:              8,
+	Portuguese:         9,
+}
+
+var languageWordlists = [...][]string{
+	English:            DefaultWordlist,
+	Japanese:           JapaneseWordlist,
+	Korean:             KoreanWordlist,
+	Spanish:            SpanishWordlist,
+	ChineseSimplified:  ChineseSimplifiedWordlist,
+	ChineseTraditional: ChineseTraditionalWordlist,
+	French:             FrenchWordlist,
+	Italian:            ItalianWordlist,
+	Czech:              CzechWordlist,
+	Portuguese:         nil,
+}
+
+var languageDictionaries = func() (dicts [len(languageWordlists)]*lazyDictionary) {
+	for l, words := range languageWordlists {
+		if words != nil {
+			dicts[l] = lazyWordlist(words)
+		}
+	}
+	return dicts
+}()
+
+// Dictionaries of the languages that aren't built in, set by
+// LoadLanguageWordlist.
+var (
+	languageMutex      sync.Mutex
+	loadedDictionaries [len(languageWordlists)]*Dictionary
+)
+
+func (l Language) valid() bool {
+	return l >= 0 && int(l) < len(languageWordlists)
+}
+
+// String returns the name of the language as used for the BIP-0039 wordlist
+// files, eg. "english" or "chinese_simplified".
+func (l Language) String() string {
+	if !l.valid() {
+		return fmt.Sprintf("Language(%d)", int(l))
+	}
+	return languageNames[l]
+}
+
+// bip85Code returns the BIP-0085 code of the language.
+func (l Language) bip85Code() uint32 {
+	return languageBIP85Codes[l]
+}
+
+// Dictionary returns the dictionary for the language, or ErrNoWordlist if
+// its wordlist isn't built in and hasn't been loaded.
+func (l Language) Dictionary() (*Dictionary, error) {
+	if !l.valid() {
+		return nil, fmt.Errorf("unsupported language %v", l)
+	}
+	if languageDictionaries[l] != nil {
+		return languageDictionaries[l].get(), nil
+	}
+	languageMutex.Lock()
+	defer languageMutex.Unlock()
+	if loadedDictionaries[l] == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoWordlist, l)
+	}
+	return loadedDictionaries[l], nil
+}
+
+// LoadLanguageWordlist sets the dictionary for a language that isn't built
+// in, eg. Portuguese from the official portuguese.txt loaded with
+// DictionaryFromFile. The dictionary must have 2048 words. The built-in
+// wordlists can't be replaced.
+func LoadLanguageWordlist(lang Language, dict *Dictionary) error {
+	if !lang.valid() {
+		return fmt.Errorf("unsupported language %v", lang)
+	}
+	if languageWordlists[lang] != nil {
+		return fmt.Errorf("the %v wordlist is built in", lang)
+	}
+	if dict.Size() != 2048 {
+		return fmt.Errorf("a BIP-0039 wordlist has 2048 words, not %d", dict.Size())
+	}
+	languageMutex.Lock()
+	defer languageMutex.Unlock()
+	loadedDictionaries[lang] = dict
+	return nil
+}
+
+// NewForLanguage generates a mnemonic object using the wordlist for the given
+// language.
+func NewForLanguage(lang Language) (*Mnemonic, error) {
+	dict, err := lang.Dictionary()
+	if err != nil {
+		return nil, err
+	}
+	return &Mnemonic{
+		dict:       dict,
+		wordLength: 11,
+		lang:       lang,
+		hasLang:    true,
+	}, nil
+}
+
+// DetectLanguage identifies which of the built-in or loaded wordlists the
+// words come from. Words are NFKD normalized before lookup, so accented characters can be
+// given in either composed or decomposed form. If the words are found in more
+// than one list, only the languages where the checksum is valid are
+// considered, and ErrAmbiguousLanguage is returned if there still isn't a
+// single match.
+func DetectLanguage(words []string) (Language, error) {
+	if len(words) == 0 {
+		return 0, ErrUnknownLanguage
+	}
+	normalized := make([]string, len(words))
+	for i, word := range words {
+		normalized[i] = norm.NFKD.String(word)
+	}
+
+	var candidates []Language
+	for lang := Language(0); lang.valid(); lang++ {
+		dict, err := lang.Dictionary()
+		if errors.Is(err, ErrNoWordlist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		found := true
+		for _, word := range normalized {
+			if _, err := dict.Index(word); err != nil {
+				found = false
+				break
+			}
+		}
+		if found {
+			candidates = append(candidates, lang)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, ErrUnknownLanguage
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	var valid []Language
+	for _, lang := range candidates {
+		m, err := NewForLanguage(lang)
+		if err != nil {
+			return 0, err
+		}
+		if ok, err := m.VerifyChecksum(normalized); err == nil && ok {
+			valid = append(valid, lang)
+		}
+	}
+	if len(valid) == 1 {
+		return valid[0], nil
+	}
+	return 0, fmt.Errorf("%w: %v", ErrAmbiguousLanguage, candidates)
+}
@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/runeaune/mnemonic"
 )
@@ -13,10 +14,29 @@ var entropySize = flag.Int("entropy_size", 256, "Number of bits of entropy used
 var wordCount = flag.Int("word_count", 0, "Number of bits of entropy used to generate phrase. Must be multiple of 32. Defaults to 256.")
 var wordFile = flag.String("word_file", "wordlist.txt", "A file containing the dictionary to use. One word per line, 2048 in total.")
 var password = flag.String("password", "", "Password used to encrypt key (optional).")
+var pgpEncode = flag.String("pgp_encode", "", "Hex data, eg. a key fingerprint, to print as PGP words instead of generating a seed.")
+var pgpDecode = flag.String("pgp_decode", "", "PGP words to print as hex data instead of generating a seed.")
 
 func main() {
 	flag.Parse()
 
+	if *pgpEncode != "" {
+		data, err := hex.DecodeString(strings.Join(strings.Fields(*pgpEncode), ""))
+		if err != nil {
+			log.Fatalf("Failed to parse hex data: %v\n", err)
+		}
+		fmt.Println(mnemonic.ListToString(mnemonic.PGPWords(data)))
+		return
+	}
+	if *pgpDecode != "" {
+		data, err := mnemonic.DataFromPGPWords(strings.Fields(*pgpDecode))
+		if err != nil {
+			log.Fatalf("Failed to decode PGP words: %v\n", err)
+		}
+		fmt.Println(strings.ToUpper(hex.EncodeToString(data)))
+		return
+	}
+
 	fmt.Printf("Generating seed words and key.\n")
 	if *password != "" {
 		fmt.Printf("Using password %q.\n", *password)
@@ -5,11 +5,26 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"sync"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// ErrWordCount is returned when the number of words can't correspond to
+	// whole bytes of entropy plus a checksum.
+	ErrWordCount = errors.New("invalid number of words")
+	// ErrUnknownWord is returned when a word isn't in the dictionary.
+	ErrUnknownWord = errors.New("word not in dictionary")
+	// ErrChecksum is returned when the checksum embedded in the words
+	// doesn't match the entropy.
+	ErrChecksum = errors.New("checksum mismatch")
 )
 
 // ListToString converts a list of strings to a space separated string
@@ -24,12 +39,25 @@ func ListToString(list []string) string {
 	return buffer.String()
 }
 
-// Mnemonic object
+// Mnemonic object. It is safe for concurrent use, but the methods returning
+// Phrase values should be preferred over the ones remembering the last words
+// generated, which may have been replaced by another goroutine.
 type Mnemonic struct {
-	// TODO Add entropy source selection
-	dict       *Dictionary
+	dict *Dictionary
+	// Bits per word, or 0 if the dictionary size isn't a power of two.
 	wordLength int
-	lastWords  []string
+	// Checksum length set by SetChecksumBits, if fixedChecksum is set.
+	fixedChecksum  bool
+	checksumLength int
+	// Language of the dictionary, if it's one of the built-in ones.
+	lang    Language
+	hasLang bool
+
+	// mutex protects the fields below.
+	mutex     sync.Mutex
+	lastWords []string
+	// Source of random data for generating words, crypto/rand if nil.
+	entropy io.Reader
 }
 
 func NewFromFile(path string) (m *Mnemonic, err error) {
@@ -37,68 +65,108 @@ func NewFromFile(path string) (m *Mnemonic, err error) {
 	if err != nil {
 		return
 	}
-
-	size := dict.Size()
-	if size == 0 || size&(size-1) != 0 {
-		err = errors.New(fmt.Sprintf("Unsupported dictionary size %d; must be power of two.", size))
-		return
-	}
-	var bits int
-	for ; size > 1; size >>= 1 {
-		bits++
-	}
-	return &Mnemonic{
-		dict:       dict,
-		wordLength: bits,
-	}, nil
+	return newMnemonic(dict)
 }
 
 // NewFromFileOrDie generates a mnemonic object based on the words from the
 // file provided. Failure to load the words is a fatal error.
 func NewFromFileOrDie(path string) *Mnemonic {
-	dict := DictionaryFromFileOrDie(path)
-	size := dict.Size()
-	if size == 0 || size&(size-1) != 0 {
-		log.Fatalf("Unsupported dictionary size %d; must be power of two.", size)
-	}
-	var bits int
-	for ; size > 1; size >>= 1 {
-		bits++
-	}
-	return &Mnemonic{
-		dict:       dict,
-		wordLength: bits,
+	m, err := newMnemonic(DictionaryFromFileOrDie(path))
+	if err != nil {
+		log.Fatal(err)
 	}
+	return m
 }
 
 // NewFromArrayOrDie generates a mnemonic object from the array of provided words
 func NewFromArrayOrDie(words []string) *Mnemonic {
-	dict := DictionaryFromArrayOrDie(words)
+	m, err := newMnemonic(DictionaryFromArrayOrDie(words))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return m
+}
+
+// newMnemonic makes a mnemonic object for the dictionary, which must have at
+// least two words. Dictionaries whose size is a power of two split the data
+// into words of whole bits like BIP-0039, others use radix encoding.
+func newMnemonic(dict *Dictionary) (*Mnemonic, error) {
 	size := dict.Size()
-	if size == 0 || size&(size-1) != 0 {
-		log.Fatalf("unsupported dictionary size %d; must be power of two", size)
+	if size < 2 {
+		return nil, fmt.Errorf("unsupported dictionary size %d; must have at least two words", size)
 	}
 	var bits int
-	for ; size > 1; size >>= 1 {
-		bits++
+	if size&(size-1) == 0 {
+		for ; size > 1; size >>= 1 {
+			bits++
+		}
 	}
 	return &Mnemonic{
 		dict:       dict,
 		wordLength: bits,
+	}, nil
+}
+
+// SetEntropySource replaces the source of random data used to generate words,
+// eg. with a hardware RNG device, a deterministic reader in tests, or several
+// sources combined with MixEntropy. Setting it to nil restores the default
+// crypto/rand source.
+func (m *Mnemonic) SetEntropySource(r io.Reader) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.entropy = r
+}
+
+// randomData reads the given number of bits, which must be divisible by 32,
+// from the entropy source.
+func (m *Mnemonic) randomData(bits int) ([]byte, error) {
+	if bits%32 != 0 {
+		return nil, fmt.Errorf("entropy size must be divisible by 32 (%d isn't)",
+			bits)
+	}
+	data := make([]byte, bits/8)
+	m.mutex.Lock()
+	source := m.entropy
+	if source == nil {
+		// crypto/rand is safe to read from concurrently.
+		m.mutex.Unlock()
+		source = rand.Reader
+	} else {
+		defer m.mutex.Unlock()
 	}
+	_, err := io.ReadFull(source, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate seed data: %v", err)
+	}
+	return data, nil
 }
 
 // GenerateFromData generates a mnemonic from the provided data array
 func (m *Mnemonic) GenerateFromData(data []byte) ([]string, error) {
+	words, err := m.encode(data)
+	if err != nil {
+		return nil, err
+	}
+	m.mutex.Lock()
+	m.lastWords = words
+	m.mutex.Unlock()
+	return words, nil
+}
+
+// encode converts data to words, with a checksum appended to the data. Radix
+// encoding takes data of any length, splitting into bits a multiple of 4
+// bytes.
+func (m *Mnemonic) encode(data []byte) ([]string, error) {
+	if m.radix() {
+		return m.radixEncode(data)
+	}
 	if len(data)%4 != 0 {
 		return nil, fmt.Errorf("data length must be divisible by 4 (%d isn't)",
 			len(data))
 	}
 	f := bitFieldFromBytes(data)
-	hash := sha256.Sum256(data)
 	hashBitCount := uint(len(data) / 4)
-	hashBits := uint64(hash[0] >> (8 - hashBitCount))
-	f.appendUint(hashBits, hashBitCount)
+	f.appendUint(checksumBits(data, hashBitCount), hashBitCount)
 
 	// bit_count * (33 / 32) must be a multiple of wordLength
 	if (uint(len(data)*8)+hashBitCount)%uint(m.wordLength) != 0 {
@@ -116,23 +184,65 @@ func (m *Mnemonic) GenerateFromData(data []byte) ([]string, error) {
 				w, err)
 		}
 	}
-	m.lastWords = words
 	return words, nil
+}
 
+// checksumBits returns the first n bits of the SHA-256 hash of the data, at
+// most 64.
+func checksumBits(data []byte, n uint) uint64 {
+	hash := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(hash[:8]) >> (64 - n)
+}
+
+// wordIndex looks up the index of a word. Words are NFKD normalized first, like
+// the built-in wordlists, so accented characters can be given in either
+// composed or decomposed form.
+func (m *Mnemonic) wordIndex(word string) (int, error) {
+	if index, err := m.dict.Index(norm.NFKD.String(word)); err == nil {
+		return index, nil
+	}
+	return m.dict.Index(word)
+}
+
+// decode converts words encoded by encode back to data, verifying the
+// checksum. Unlike EntropyFromWords it accepts any number of words that
+// corresponds to whole bytes of data.
+func (m *Mnemonic) decode(words []string) ([]byte, error) {
+	bits := len(words) * m.wordLength
+	if !m.radix() && (bits == 0 || bits%33 != 0) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+	indexes := make([]int, len(words))
+	for i, word := range words {
+		index, err := m.wordIndex(word)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrUnknownWord,
+				word, i)
+		}
+		indexes[i] = index
+	}
+	if m.radix() {
+		return m.radixDecode(indexes)
+	}
+	data, checksum, checksumLength, err := m.getDataChecksum(words)
+	if err != nil {
+		return nil, err
+	}
+	if checksumBits(data, uint(checksumLength)) != checksum {
+		return nil, ErrChecksum
+	}
+	entropy := make([]byte, len(data))
+	copy(entropy, data)
+	return entropy, nil
 }
 
 // GenerateEntropy generates a list of random words from the loaded dictionary
 // corresponding to given number of bits of entropy plus a checksum. The bits
 // of entropy must be divisible with 32.
 func (m *Mnemonic) GenerateEntropy(bits int) ([]string, error) {
-	if bits%32 != 0 {
-		return nil, fmt.Errorf("entropy size must be divisible by 32 (%d isn't)",
-			bits)
-	}
-	data := make([]byte, bits/8)
-	_, err := rand.Read(data)
+	data, err := m.randomData(bits)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate seed data: %v", err)
+		return nil, err
 	}
 	return m.GenerateFromData(data)
 }
@@ -140,8 +250,22 @@ func (m *Mnemonic) GenerateEntropy(bits int) ([]string, error) {
 // GenerateWords generates count random words, corresponding to count *
 // dictionary_bits (number of bits of entropy in the dictionary, eg. 11 for a
 // dictionary with 2048 words). The count must be divisible with
-// 33/dictionary_bits (3 for dictionary with 2048 words).
+// 33/dictionary_bits (3 for dictionary with 2048 words). With radix encoding
+// the count must be one that some multiple of 32 bits is encoded as.
 func (m *Mnemonic) GenerateWords(count int) ([]string, error) {
+	if m.radix() {
+		length := 0
+		for _, l := range m.radixDataLengths(count) {
+			if l%4 == 0 {
+				length = l
+			}
+		}
+		if length == 0 {
+			return nil, fmt.Errorf("%w: no entropy size is encoded as %d words",
+				ErrWordCount, count)
+		}
+		return m.GenerateEntropy(8 * length)
+	}
 	entropy := count * m.wordLength
 	if entropy%33 != 0 {
 		return nil, fmt.Errorf("word count needs to be divisible by %d",
@@ -153,7 +277,7 @@ func (m *Mnemonic) GenerateWords(count int) ([]string, error) {
 func (m *Mnemonic) getDataChecksum(words []string) ([]byte, uint64, int, error) {
 	f := bitField{}
 	for _, word := range words {
-		index, err := m.dict.Index(word)
+		index, err := m.wordIndex(word)
 		if err != nil {
 			return nil, 0, 0, fmt.Errorf("word not found in dictionary: %v", err)
 		}
@@ -178,17 +302,43 @@ func (m *Mnemonic) getDataChecksum(words []string) ([]byte, uint64, int, error)
 // valid checksum. If this is the case, they were likely generated using the
 // BIP-0039 algorithm.
 func (m *Mnemonic) VerifyChecksum(words []string) (bool, error) {
+	if m.radix() {
+		_, err := m.decode(words)
+		if errors.Is(err, ErrChecksum) {
+			return false, nil
+		}
+		return err == nil, err
+	}
 	data, checksum, checksumLength, err := m.getDataChecksum(words)
 	if err != nil {
 		return false, err
 	}
-	hash := sha256.Sum256(data)
-	hashBits := uint64(hash[0] >> uint(8-checksumLength))
-	if hashBits == checksum {
-		return true, nil
+	return checksumBits(data, uint(checksumLength)) == checksum, nil
+}
+
+// EntropyFromWords recovers the entropy the list of words was generated from.
+// It fails with ErrWordCount, ErrUnknownWord or ErrChecksum (possibly wrapped)
+// if the words are not a valid mnemonic for the loaded dictionary.
+func (m *Mnemonic) EntropyFromWords(words []string) ([]byte, error) {
+	if !m.validWordCount(len(words)) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
 	}
+	return m.decode(words)
+}
 
-	return false, nil
+// validWordCount tells whether a phrase of 4 to 32 bytes of entropy can have
+// n words. Radix encoded phrases can have 1 to 32 bytes.
+func (m *Mnemonic) validWordCount(n int) bool {
+	if m.radix() {
+		for _, length := range m.radixDataLengths(n) {
+			if length >= 1 && length <= 32 {
+				return true
+			}
+		}
+		return false
+	}
+	bits := n * m.wordLength
+	return bits != 0 && bits%33 == 0 && bits/33 <= 8
 }
 
 // SeedFromWordsPassword generates a 512 bit key seed from the word list and
@@ -198,22 +348,30 @@ func SeedFromWordsPassword(words []string, password string) []byte {
 }
 
 // SeedFromPhrasePassword generates a 512 bit key seed from the phrase and
-// password provided.
+// password provided. Both are NFKD normalized first as required by BIP-0039,
+// so composed and decomposed characters, full-width forms and the ideographic
+// space used to separate Japanese words give the same seed as their
+// compatibility equivalents.
 func SeedFromPhrasePassword(phrase, password string) []byte {
-	salt := []byte("mnemonic" + password)
-	return pbkdf2.Key([]byte(phrase), salt, 2048, 64, sha512.New)
+	salt := norm.NFKD.Bytes([]byte("mnemonic" + password))
+	return pbkdf2.Key(norm.NFKD.Bytes([]byte(phrase)), salt, 2048, 64,
+		sha512.New)
 }
 
 // GenerateSeedWithPassword generates a 512 bit (64 byte) key based on the last
 // generated words and encrypted with a password. If no words have been
 // generated, new ones will be generated with 256 bits of entropy.
 func (m *Mnemonic) GenerateSeedWithPassword(password string) ([]string, []byte, error) {
-	if m.lastWords == nil {
-		_, err := m.GenerateEntropy(256)
+	m.mutex.Lock()
+	words := m.lastWords
+	m.mutex.Unlock()
+	if words == nil {
+		var err error
+		words, err = m.GenerateEntropy(256)
 		if err != nil {
 			return nil, nil, fmt.Errorf("Seed generation failed: %v", err)
 		}
 	}
-	seed := SeedFromWordsPassword(m.lastWords, password)
-	return m.lastWords, seed, nil
+	seed := SeedFromWordsPassword(words, password)
+	return words, seed, nil
 }
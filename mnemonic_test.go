@@ -1,12 +1,22 @@
 package mnemonic
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"strings"
 
 	"testing"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 type testSet struct {
@@ -93,3 +103,1134 @@ func TestMnemonicGeneration(t *testing.T) {
 
 	}
 }
+
+func TestEntropyFromWords(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	file, err := ioutil.ReadFile("test_vectors.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+
+	var tests testSet
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+
+	for i, test := range tests.English {
+		data, err := m.EntropyFromWords(strings.Split(test[1], " "))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to recover entropy: %v", i, err)
+		}
+		if encoded := hex.EncodeToString(data); encoded != test[0] {
+			t.Errorf("Test %d: Entropy doesn't match: Got %q, expected %q.",
+				i, encoded, test[0])
+		}
+	}
+
+	invalid := []struct {
+		phrase string
+		err    error
+	}{
+		{"abandon abandon", ErrWordCount},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ErrWordCount},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandoned", ErrUnknownWord},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", ErrChecksum},
+	}
+	for i, test := range invalid {
+		_, err := m.EntropyFromWords(strings.Split(test.phrase, " "))
+		if !errors.Is(err, test.err) {
+			t.Errorf("Invalid test %d: Got error %v, expected %v.", i, err,
+				test.err)
+		}
+	}
+}
+
+func TestSeedNormalization(t *testing.T) {
+	file, err := ioutil.ReadFile("test_vectors_intl.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+
+	var tests map[string][][]string
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+
+	for lang, vectors := range tests {
+		for i, test := range vectors {
+			phrase, password := test[1], test[2]
+			inputs := [][2]string{
+				{phrase, password},
+				{norm.NFC.String(phrase), norm.NFC.String(password)},
+				{ListToString(strings.Fields(phrase)), password},
+			}
+			for j, in := range inputs {
+				encoded := hex.EncodeToString(SeedFromPhrasePassword(in[0], in[1]))
+				if encoded != test[3] {
+					t.Errorf("Test %s %d, input %d: Key doesn't match: Got %q, expected %q.",
+						lang, i, j, encoded, test[3])
+				}
+			}
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	file, err := ioutil.ReadFile("test_vectors_intl.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+
+	var tests map[string][][]string
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+
+	for _, lang := range Languages {
+		dict, err := lang.Dictionary()
+		if err != nil {
+			t.Fatalf("Failed to load dictionary for %v: %v", lang, err)
+		}
+		if dict.Size() != 2048 {
+			t.Errorf("Unexpected dictionary size for %v: %d", lang, dict.Size())
+		}
+		m, err := NewForLanguage(lang)
+		if err != nil {
+			t.Fatalf("Failed to create mnemonic for %v: %v", lang, err)
+		}
+		for i, test := range tests[lang.String()] {
+			data, err := hex.DecodeString(test[0])
+			if err != nil {
+				t.Fatalf("Test %v %d: Failed to decode hex encoded data %q: %v",
+					lang, i, test[0], err)
+			}
+			words, err := m.GenerateFromData(data)
+			if err != nil {
+				t.Fatalf("Test %v %d: Failed to generator words: %v", lang, i, err)
+			}
+			if str := ListToString(words); str != ListToString(strings.Fields(test[1])) {
+				t.Errorf("Test %v %d: Words don't match: Got %q, expected %q.",
+					lang, i, str, test[1])
+			}
+			detected, err := DetectLanguage(strings.Fields(norm.NFC.String(test[1])))
+			if err != nil {
+				t.Fatalf("Test %v %d: Failed to detect language: %v", lang, i, err)
+			}
+			if detected != lang {
+				t.Errorf("Test %v %d: Detected wrong language %v.", lang, i, detected)
+			}
+			// Composed (NFC) input is found in the decomposed wordlists.
+			composed := strings.Fields(norm.NFC.String(test[1]))
+			entropy, err := m.EntropyFromWords(composed)
+			if err != nil {
+				t.Fatalf("Test %v %d: Failed to decode NFC words: %v", lang, i, err)
+			}
+			if !bytes.Equal(entropy, data) {
+				t.Errorf("Test %v %d: Entropy doesn't match: Got %x, expected %x.",
+					lang, i, entropy, data)
+			}
+			if ok, err := m.VerifyChecksum(composed); err != nil || !ok {
+				t.Errorf("Test %v %d: Checksum of NFC words not valid: %v", lang, i, err)
+			}
+		}
+	}
+
+	detected, err := DetectLanguage(strings.Fields("legal winner thank year wave sausage worth useful legal winner thank yellow"))
+	if err != nil || detected != English {
+		t.Errorf("Failed to detect English phrase: Got %v (%v).", detected, err)
+	}
+	if _, err := DetectLanguage([]string{"abandon", "no-such-word"}); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("Unexpected error for unknown words: %v", err)
+	}
+
+	// Portuguese isn't built in, so it can only be used once loaded. A
+	// stand-in list is enough to check the plumbing.
+	if _, err := NewForLanguage(Portuguese); !errors.Is(err, ErrNoWordlist) {
+		t.Errorf("Unexpected error for Portuguese before loading: %v", err)
+	}
+	if err := LoadLanguageWordlist(English, DictionaryFromArrayOrDie(numberedWords(2048))); err == nil {
+		t.Errorf("Replaced the built-in English wordlist.")
+	}
+	if err := LoadLanguageWordlist(Portuguese, DictionaryFromArrayOrDie(numberedWords(1024))); err == nil {
+		t.Errorf("Loaded a Portuguese wordlist of 1024 words.")
+	}
+	if err := LoadLanguageWordlist(Portuguese, DictionaryFromArrayOrDie(numberedWords(2048))); err != nil {
+		t.Fatalf("Failed to load Portuguese wordlist: %v", err)
+	}
+	m, err := NewForLanguage(Portuguese)
+	if err != nil {
+		t.Fatalf("Failed to create mnemonic for Portuguese: %v", err)
+	}
+	words, err := m.GenerateFromData(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Failed to generate Portuguese words: %v", err)
+	}
+	if words[0] != "w0000" || words[11] != "w0003" {
+		t.Errorf("Unexpected Portuguese words %q.", words)
+	}
+	if detected, err := DetectLanguage(words); err != nil || detected != Portuguese {
+		t.Errorf("Failed to detect Portuguese phrase: Got %v (%v).", detected, err)
+	}
+}
+
+func TestDictionaryUnsorted(t *testing.T) {
+	d := &Dictionary{}
+	if err := d.LoadFromArray([]string{"zoo", "abandon", "mix"}); err != nil {
+		t.Fatalf("Failed to load unsorted words: %v", err)
+	}
+	for i, word := range []string{"zoo", "abandon", "mix"} {
+		j, err := d.Index(word)
+		if err != nil {
+			t.Fatalf("Failed to get word index for %q: %v", word, err)
+		}
+		if j != i {
+			t.Errorf("Index mismatch; expected %d, got %d.", i, j)
+		}
+	}
+	if _, err := d.Index("about"); err == nil {
+		t.Errorf("Expected error looking up missing word.")
+	}
+	if err := d.LoadFromArray([]string{"zoo", "abandon", "zoo"}); err == nil {
+		t.Errorf("Expected error loading duplicate words.")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	dict := DictionaryFromArrayOrDie(DefaultWordlist)
+	tests := []struct {
+		typed, expected string
+	}{
+		{"abandn", "abandon"},
+		{"wuality", "quality"},
+		{"lgeal", "legal"},
+		{"zoo", "zoo"},
+		{"Thank", "thank"},
+		{"sausag", "sausage"},
+	}
+	for i, test := range tests {
+		words := dict.Suggest(test.typed, 3)
+		if len(words) != 3 {
+			t.Fatalf("Test %d: Expected 3 suggestions, got %q.", i, words)
+		}
+		if words[0] != test.expected {
+			t.Errorf("Test %d: Got suggestions %q for %q, expected %q first.",
+				i, words, test.typed, test.expected)
+		}
+	}
+	for _, max := range []int{0, -1} {
+		if words := dict.Suggest("abc", max); len(words) != 0 {
+			t.Errorf("Expected no suggestions for max %d, got %q.", max, words)
+		}
+	}
+}
+
+func TestCorrectPhrase(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	phrase := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	tests := []string{
+		phrase,
+		"legal winner thsnk year wave sausage worth useful legal winner thank yellow",
+		"legal winner thank year wave sausag worth useful legal winer thank yellow",
+		"Legal winner thank year wave sausage worth useful legal winner thank yelow",
+	}
+	for i, test := range tests {
+		phrases, err := m.CorrectPhrase(strings.Split(test, " "), 5)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to correct phrase: %v", i, err)
+		}
+		if len(phrases) == 0 || ListToString(phrases[0]) != phrase {
+			t.Errorf("Test %d: Got corrections %q, expected %q first.", i,
+				phrases, phrase)
+		}
+	}
+
+	// A valid word in the wrong place is only detected by the checksum.
+	wrong := strings.Split(phrase, " ")
+	wrong[3] = "wear"
+	phrases, err := m.CorrectPhrase(wrong, 100)
+	if err != nil {
+		t.Fatalf("Failed to correct phrase: %v", err)
+	}
+	found := false
+	for _, p := range phrases {
+		if ListToString(p) == phrase {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected %q among corrections, got %q.", phrase, phrases)
+	}
+
+	misspelled := strings.Split(phrase, " ")
+	for i := range misspelled {
+		misspelled[i] += "x"
+	}
+	if _, err := m.CorrectPhrase(misspelled, 5); !errors.Is(err, ErrTooManyCorrections) {
+		t.Errorf("Expected error for every word misspelled, got %v.", err)
+	}
+}
+
+func TestPrefixes(t *testing.T) {
+	dict := DictionaryFromArrayOrDie(DefaultWordlist)
+	if n := dict.UniquePrefixLength(); n != 4 {
+		t.Errorf("Unexpected unique prefix length %d, expected 4.", n)
+	}
+	for _, word := range DefaultWordlist {
+		runes := []rune(word)
+		if len(runes) > 4 {
+			runes = runes[:4]
+		}
+		expanded, err := dict.Expand(string(runes))
+		if err != nil {
+			t.Fatalf("Failed to expand %q: %v", string(runes), err)
+		}
+		if expanded != word {
+			t.Errorf("Expanded %q to %q, expected %q.", string(runes), expanded, word)
+		}
+		short, err := dict.Abbreviate(word)
+		if err != nil {
+			t.Fatalf("Failed to abbreviate %q: %v", word, err)
+		}
+		if expanded, _ := dict.Expand(short); expanded != word {
+			t.Errorf("Abbreviation %q of %q expands to %q.", short, word, expanded)
+		}
+	}
+	if _, err := dict.Expand("ab"); !errors.Is(err, ErrAmbiguousPrefix) {
+		t.Errorf("Unexpected error for ambiguous prefix: %v", err)
+	}
+	if _, err := dict.Expand("xyz"); !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("Unexpected error for unknown prefix: %v", err)
+	}
+
+	m := NewFromArrayOrDie(DefaultWordlist)
+	words, err := m.ParsePhrase("lega winn thank year wave saus wort usef legal winn than yell")
+	if err != nil {
+		t.Fatalf("Failed to parse phrase: %v", err)
+	}
+	expected := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	if str := ListToString(words); str != expected {
+		t.Errorf("Parsed phrase %q, expected %q.", str, expected)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	phrase := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	words := strings.Split(phrase, " ")
+	seed := SeedFromWordsPassword(words, "TREZOR")
+
+	missing := strings.Split(phrase, " ")
+	missing[4] = Placeholder
+	var tried, total uint64
+	phrases, err := m.Recover(context.Background(), missing, RecoveryOptions{
+		Progress: func(t, n uint64) {
+			tried, total = t, n
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to recover phrase: %v", err)
+	}
+	if tried != 2048 || total != 2048 {
+		t.Errorf("Unexpected progress %d of %d, expected 2048 of 2048.", tried, total)
+	}
+	found := false
+	for _, p := range phrases {
+		if ok, _ := m.VerifyChecksum(p); !ok {
+			t.Errorf("Recovered phrase %q has invalid checksum.", p)
+		}
+		if ListToString(p) == phrase {
+			found = true
+		}
+	}
+	if !found || len(phrases) < 2 {
+		t.Errorf("Expected %q among several recovered phrases, got %d.", phrase,
+			len(phrases))
+	}
+
+	missing[11] = Placeholder
+	candidates := make([][]string, len(words))
+	candidates[4] = []string{"wave", "wage", "wall"}
+	phrases, err = m.Recover(context.Background(), missing, RecoveryOptions{
+		Candidates: candidates,
+		Password:   "TREZOR",
+		Match:      MatchNickname(Nickname(seed)),
+	})
+	if err != nil {
+		t.Fatalf("Failed to recover phrase: %v", err)
+	}
+	if len(phrases) != 1 || ListToString(phrases[0]) != phrase {
+		t.Errorf("Got recovered phrases %q, expected only %q.", phrases, phrase)
+	}
+
+	missing[4] = words[4]
+	phrases, err = m.Recover(context.Background(), missing, RecoveryOptions{
+		Password: "TREZOR",
+		Match:    MatchSeedPrefix(seed[:4]),
+		Workers:  1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to recover phrase: %v", err)
+	}
+	if len(phrases) != 1 || ListToString(phrases[0]) != phrase {
+		t.Errorf("Got recovered phrases %q, expected only %q.", phrases, phrase)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.Recover(ctx, missing, RecoveryOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Unexpected error after cancelling: %v", err)
+	}
+}
+
+func TestRecoverOrder(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	phrase := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	words := strings.Split(phrase, " ")
+	seed := SeedFromWordsPassword(words, "")
+
+	swapped := strings.Split(phrase, " ")
+	swapped[2], swapped[3] = swapped[3], swapped[2]
+	if ok, _ := m.VerifyChecksum(swapped); ok {
+		t.Fatalf("Swapped phrase unexpectedly valid.")
+	}
+	phrases, err := m.RecoverOrder(swapped, ReorderOptions{})
+	if err != nil {
+		t.Fatalf("Failed to recover order: %v", err)
+	}
+	if len(phrases) == 0 || ListToString(phrases[0]) != phrase {
+		t.Errorf("Expected %q as first reordering, got %q.", phrase, phrases)
+	}
+	swapped[2], swapped[3] = swapped[3], swapped[2]
+	swapped[1], swapped[9] = swapped[9], swapped[1]
+	phrases, err = m.RecoverOrder(swapped, ReorderOptions{
+		Match: MatchSeedPrefix(seed[:4]),
+	})
+	if err != nil {
+		t.Fatalf("Failed to recover order: %v", err)
+	}
+	if len(phrases) != 1 || ListToString(phrases[0]) != phrase {
+		t.Errorf("Got reorderings %q, expected only %q.", phrases, phrase)
+	}
+
+	short, err := m.GenerateFromData([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	if err != nil {
+		t.Fatalf("Failed to generate words: %v", err)
+	}
+	shortSeed := SeedFromWordsPassword(short, "")
+	shuffled := []string{short[3], short[0], short[5], short[1], short[4], short[2]}
+	phrases, err = m.RecoverOrder(shuffled, ReorderOptions{
+		Permute: true,
+		Match:   MatchSeedPrefix(shortSeed[:4]),
+	})
+	if err != nil {
+		t.Fatalf("Failed to recover order: %v", err)
+	}
+	if len(phrases) != 1 || ListToString(phrases[0]) != ListToString(short) {
+		t.Errorf("Got reorderings %q, expected only %q.", phrases, short)
+	}
+	if _, err := m.RecoverOrder(words, ReorderOptions{Permute: true}); err == nil {
+		t.Errorf("Expected error trying all orderings of 12 words.")
+	}
+}
+
+func TestEntropySource(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	data, _ := hex.DecodeString("7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f")
+	m.SetEntropySource(bytes.NewReader(data))
+	words, err := m.GenerateEntropy(128)
+	if err != nil {
+		t.Fatalf("Failed to generate words: %v", err)
+	}
+	expected := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	if str := ListToString(words); str != expected {
+		t.Errorf("Words don't match: Got %q, expected %q.", str, expected)
+	}
+	if _, err := m.GenerateEntropy(128); err == nil {
+		t.Errorf("Expected error when entropy source is exhausted.")
+	}
+
+	a := bytes.Repeat([]byte{0xaa}, 64)
+	b := bytes.Repeat([]byte{0x55}, 64)
+	mixed := make([]byte, 40)
+	if _, err := io.ReadFull(MixEntropy(bytes.NewReader(a), bytes.NewReader(b)), mixed); err != nil {
+		t.Fatalf("Failed to read mixed entropy: %v", err)
+	}
+	h := sha256.New()
+	h.Write(make([]byte, 8))
+	h.Write(a[:32])
+	h.Write(b[:32])
+	if first := h.Sum(nil); !bytes.Equal(mixed[:32], first) {
+		t.Errorf("Mixed entropy doesn't match: Got %x, expected %x.", mixed[:32], first)
+	}
+	if _, err := io.ReadFull(MixEntropy(bytes.NewReader(a), bytes.NewReader(b[:16])), mixed); err == nil {
+		t.Errorf("Expected error when a source is exhausted.")
+	}
+
+	m.SetEntropySource(nil)
+	if _, err := m.GenerateEntropy(256); err != nil {
+		t.Errorf("Failed to generate words from default source: %v", err)
+	}
+}
+
+func TestDiceAndCoins(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	rolls := strings.Repeat("3615224", 7) + "5"
+	words, err := m.GenerateFromDice(rolls, 6, 128)
+	if err != nil {
+		t.Fatalf("Failed to generate words from dice: %v", err)
+	}
+	hash := sha256.Sum256([]byte(rolls))
+	expected, _ := m.GenerateFromData(hash[:16])
+	if ListToString(words) != ListToString(expected) {
+		t.Errorf("Words don't match: Got %q, expected %q.", words, expected)
+	}
+	spaced, err := m.GenerateFromDice(strings.Join(strings.Split(rolls, ""), " "), 6, 128)
+	if err != nil || ListToString(spaced) != ListToString(words) {
+		t.Errorf("Spaces in rolls changed the words: Got %q (%v).", spaced, err)
+	}
+
+	_, err = EntropyFromDice(rolls[1:], 6, 128)
+	var insufficient *InsufficientEntropyError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("Unexpected error for too few rolls: %v", err)
+	}
+	if insufficient.Needed != 128 || insufficient.Collected != DiceBits(49, 6) {
+		t.Errorf("Unexpected entropy report %v.", insufficient)
+	}
+	// 99 d6 rolls, 255.9 bits, are enough for 24 words like on Coldcard.
+	if _, err := EntropyFromDice(strings.Repeat("3615224", 14)+"3", 6, 256); err != nil {
+		t.Errorf("Failed to get 256 bits from 99 rolls: %v", err)
+	}
+	if _, err := EntropyFromDice(strings.Repeat("3615224", 14), 6, 256); err == nil {
+		t.Errorf("Expected error for 256 bits from 98 rolls.")
+	}
+	if _, err := EntropyFromDice("1234567", 6, 128); err == nil {
+		t.Errorf("Expected error for roll out of range.")
+	}
+	if _, err := EntropyFromDice(strings.Repeat("20 ", 30), 20, 128); err != nil {
+		t.Errorf("Failed to get entropy from d20: %v", err)
+	}
+
+	words, err = m.GenerateFromCoins(strings.Repeat("THHH HHHH ", 16), 128)
+	if err != nil {
+		t.Fatalf("Failed to generate words from coins: %v", err)
+	}
+	if str := ListToString(words); str != "legal winner thank year wave sausage worth useful legal winner thank yellow" {
+		t.Errorf("Unexpected words from coin flips: %q", str)
+	}
+	if _, err := EntropyFromCoins(strings.Repeat("1", 127), 128); !errors.As(err, &insufficient) {
+		t.Errorf("Unexpected error for too few flips: %v", err)
+	}
+}
+
+func TestPhrase(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	file, err := ioutil.ReadFile("test_vectors.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+
+	var tests testSet
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+
+	for i, test := range tests.English {
+		data, _ := hex.DecodeString(test[0])
+		p, err := m.PhraseFromData(data)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to generate phrase: %v", i, err)
+		}
+		if p.String() != test[1] {
+			t.Errorf("Test %d: Words don't match: Got %q, expected %q.",
+				i, p.String(), test[1])
+		}
+		if encoded := hex.EncodeToString(p.Seed("TREZOR")); encoded != test[2] {
+			t.Errorf("Test %d: Key doesn't match: Got %q, expected %q.",
+				i, encoded, test[2])
+		}
+		parsed, err := m.PhraseFromWords(p.Words())
+		if err != nil {
+			t.Fatalf("Test %d: Failed to parse phrase: %v", i, err)
+		}
+		if !bytes.Equal(parsed.Entropy(), data) {
+			t.Errorf("Test %d: Entropy doesn't match: Got %x, expected %x.",
+				i, parsed.Entropy(), data)
+		}
+	}
+
+	jp, err := NewForLanguage(Japanese)
+	if err != nil {
+		t.Fatalf("Failed to create mnemonic: %v", err)
+	}
+	p, err := jp.PhraseFromData(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Failed to generate phrase: %v", err)
+	}
+	if lang, ok := p.Language(); !ok || lang != Japanese {
+		t.Errorf("Unexpected phrase language %v.", lang)
+	}
+	if !strings.Contains(p.String(), "　") {
+		t.Errorf("Japanese phrase %q not separated by ideographic spaces.", p.String())
+	}
+}
+
+func TestConcurrentGeneration(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	done := make(chan error)
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			var err error
+			for j := 0; j < 20 && err == nil; j++ {
+				switch (i + j) % 4 {
+				case 0:
+					var p Phrase
+					p, err = m.NewPhrase(128)
+					if err == nil && len(p.Words()) != 12 {
+						err = fmt.Errorf("got %d words", len(p.Words()))
+					}
+				case 1:
+					_, err = m.GenerateEntropy(256)
+				case 2:
+					_, _, err = m.GenerateSeedWithPassword("")
+				case 3:
+					m.SetEntropySource(MixEntropy(rand.Reader))
+				}
+			}
+			done <- err
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("Concurrent generation failed: %v", err)
+		}
+	}
+}
+
+func TestElectrum(t *testing.T) {
+	// Test vectors from Electrum.
+	tests := []struct {
+		phrase, passphrase string
+		version            ElectrumType
+		seed               string
+	}{
+		{"wild father tree among universe such mobile favorite target dynamic credit identify", "",
+			ElectrumSegwit, "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756"},
+		{"wild father tree among universe such mobile favorite target dynamic credit identify", "Did you ever hear the tragedy of Darth Plagueis the Wise?",
+			ElectrumSegwit, "4aa29f2aeb0127efb55138ab9e7be83b36750358751906f86c662b21a1ea1370f949e6d1a12fa56d3d93cadda93038c76ac8118597364e46f5156fde6183c82f"},
+		{"眼 悲 叛 改 节 跃 衡 响 疆 股 遂 冬", "",
+			ElectrumSegwit, "0b9077db7b5a50dbb6f61821e2d35e255068a5847e221138048a20e12d80b673ce306b6fe7ac174ebc6751e11b7037be6ee9f17db8040bb44f8466d519ce2abf"},
+		{"眼 悲 叛 改 节 跃 衡 响 疆 股 遂 冬", "给我一些测试向量谷歌",
+			ElectrumSegwit, "6c03dd0615cf59963620c0af6840b52e867468cc64f20a1f4c8155705738e87b8edb0fc8a6cee4085776cb3a629ff88bb1a38f37085efdbf11ce9ec5a7fa5f71"},
+		{"almíbar tibio superar vencer hacha peatón príncipe matar consejo polen vehículo odisea", "",
+			ElectrumStandard, "18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a"},
+	}
+	for i, test := range tests {
+		words := strings.Split(test.phrase, " ")
+		version, ok := ElectrumVersion(words)
+		if !ok || version != test.version {
+			t.Errorf("Test %d: Version doesn't match: Got %v (%t), expected %v.",
+				i, version, ok, test.version)
+		}
+		seed := ElectrumSeedFromWordsPassword(words, test.passphrase)
+		if encoded := hex.EncodeToString(seed); encoded != test.seed {
+			t.Errorf("Test %d: Seed doesn't match: Got %q, expected %q.",
+				i, encoded, test.seed)
+		}
+	}
+
+	m := NewFromArrayOrDie(DefaultWordlist)
+	for _, version := range []ElectrumType{ElectrumStandard, ElectrumSegwit, Electrum2FA} {
+		words, err := m.GenerateElectrum(version)
+		if err != nil {
+			t.Fatalf("Failed to generate %v seed: %v", version, err)
+		}
+		if len(words) != 12 {
+			t.Errorf("Unexpected %v seed length %d.", version, len(words))
+		}
+		if detected, ok := ElectrumVersion(words); !ok || detected != version {
+			t.Errorf("Generated seed %q has version %v, expected %v.",
+				strings.Join(words, " "), detected, version)
+		}
+		if format := m.DetectFormat(words); format != FormatElectrum {
+			t.Errorf("Generated seed detected as %v.", format)
+		}
+	}
+
+	bip39 := strings.Split("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", " ")
+	if format := m.DetectFormat(bip39); format != FormatBIP39 {
+		t.Errorf("BIP-0039 phrase detected as %v.", format)
+	}
+	electrum := strings.Split(tests[0].phrase, " ")
+	if format := m.DetectFormat(electrum); format != FormatElectrum {
+		t.Errorf("Electrum seed detected as %v.", format)
+	}
+	if format := m.DetectFormat([]string{"not", "a", "seed"}); format != FormatUnknown {
+		t.Errorf("Invalid phrase detected as %v.", format)
+	}
+}
+
+func TestMonero(t *testing.T) {
+	m := NewMoneroEnglish()
+	if _, err := NewMonero(DictionaryFromArrayOrDie(DefaultWordlist), 4); err == nil {
+		t.Errorf("Expected error for 2048 word dictionary.")
+	}
+
+	// Seeds and private spend keys of Monero wallets.
+	for i, test := range []struct {
+		seed, key string
+	}{
+		{"sequence atlas unveil summon pebbles tuesday beer rudely snake rockets different fuselage woven tagged bested dented vegan hover rapid fawns obvious muppet randomly seasons randomly",
+			"b0ef6bd527b9b23b9ceef70dc8b4cd1ee83ca14541964e764ad23f5151204f0f"},
+		{"hemlock jubilee eden hacksaw boil superior inroads epoxy exhale orders cavernous second brunt saved richly lower upgrade hitched launching deepest mostly playful layout lower eden",
+			"29adefc8f67515b4b4bf48031780ab9d071d24f8a674b879ce7f245c37523807"},
+		{"vocal either anvil films dolphin zeal bacon cuisine quote syndrome rejoices envy okay pancakes tulips lair greater petals organs enmity dedicated oust thwart tomorrow tomorrow",
+			"722bbfcf99a9b2c9e700ce857850dd8c4c94c73dca8d914c603f5fee0e365803"},
+	} {
+		key, err := m.Decode(strings.Fields(test.seed))
+		if err != nil {
+			t.Fatalf("Test %d: Failed to decode seed: %v", i, err)
+		}
+		if encoded := hex.EncodeToString(key); encoded != test.key {
+			t.Errorf("Test %d: Key doesn't match: Got %q, expected %q.", i, encoded, test.key)
+		}
+		words, err := m.Encode(key)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to encode key: %v", i, err)
+		}
+		if got := strings.Join(words, " "); got != test.seed {
+			t.Errorf("Test %d: Seed doesn't match: Got %q, expected %q.", i, got, test.seed)
+		}
+	}
+
+	key, _ := hex.DecodeString("00000000ffffffff0100000002000000fe000000ff0000003f0600004d610c00")
+	words, err := m.Encode(key)
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+	if len(words) != 25 {
+		t.Fatalf("Unexpected number of words %d.", len(words))
+	}
+	// Zero is the first word three times, and 1 is the second word
+	// followed by two more as each word is offset by the previous.
+	if words[0] != "abbey" || words[1] != "abbey" || words[2] != "abbey" {
+		t.Errorf("Unexpected words %q for zero.", words[:3])
+	}
+	if words[6] != "abducts" || words[7] != "abducts" || words[8] != "abducts" {
+		t.Errorf("Unexpected words %q for 1.", words[6:9])
+	}
+	decoded, err := m.Decode(words)
+	if err != nil {
+		t.Fatalf("Failed to decode words: %v", err)
+	}
+	if !bytes.Equal(decoded, key) {
+		t.Errorf("Round trip doesn't match: Got %x, expected %x.", decoded, key)
+	}
+	abbreviated := make([]string, len(words))
+	for i, word := range words {
+		abbreviated[i] = word[:3]
+	}
+	if decoded, err := m.Decode(abbreviated); err != nil || !bytes.Equal(decoded, key) {
+		t.Errorf("Abbreviated round trip doesn't match: Got %x (%v).", decoded, err)
+	}
+	if decoded, err := m.Decode(words[:24]); err != nil || !bytes.Equal(decoded, key) {
+		t.Errorf("Round trip without checksum doesn't match: Got %x (%v).", decoded, err)
+	}
+
+	wrong := append([]string(nil), words...)
+	for _, candidate := range words[:24] {
+		if candidate != words[24] {
+			wrong[24] = candidate
+			break
+		}
+	}
+	if _, err := m.Decode(wrong); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected checksum error, got %v.", err)
+	}
+	for _, n := range []int{0, 1, 2, 5} {
+		if _, err := m.Decode(words[:n]); !errors.Is(err, ErrWordCount) {
+			t.Errorf("Expected word count error for %d words, got %v.", n, err)
+		}
+	}
+
+	m.SetEntropySource(bytes.NewReader(bytes.Repeat([]byte{0xff}, 32)))
+	words, generated, err := m.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	// 2^256 - 1 modulo the ed25519 group order.
+	expected := "1c95988d7431ecd670cf7d73f45befc6feffffffffffffffffffffffffffff0f"
+	if encoded := hex.EncodeToString(generated); encoded != expected {
+		t.Errorf("Generated key doesn't match: Got %q, expected %q.", encoded, expected)
+	}
+	if decoded, err := m.Decode(words); err != nil || !bytes.Equal(decoded, generated) {
+		t.Errorf("Generated words don't match key: Got %x (%v).", decoded, err)
+	}
+}
+
+func numberedWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%04d", i)
+	}
+	return words
+}
+
+func TestRadix(t *testing.T) {
+	m := NewFromArrayOrDie(numberedWords(7776))
+	// 2^132 + 3: the leading 1 bit, 128 bits of zeros and the 4 bit checksum 3
+	// of their hash.
+	words, err := m.GenerateFromData(make([]byte, 16))
+	if err != nil {
+		t.Fatalf("Failed to encode data: %v", err)
+	}
+	expected := "w0006 w5722 w3984 w6909 w5882 w4106 w4411 w5913 w2586 w1026 w6979"
+	if got := strings.Join(words, " "); got != expected {
+		t.Errorf("Words don't match: Got %q, expected %q.", got, expected)
+	}
+	wrong := append([]string(nil), words...)
+	wrong[10] = "w6980"
+	if _, err := m.EntropyFromWords(wrong); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected checksum error, got %v.", err)
+	}
+	wrong[0], wrong[10] = "w7775", "w6979"
+	if _, err := m.EntropyFromWords(wrong); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected checksum error for too large value, got %v.", err)
+	}
+	if ok, err := m.VerifyChecksum(wrong); ok || err != nil {
+		t.Errorf("Expected invalid checksum, got %v (%v).", ok, err)
+	}
+
+	for i, test := range []struct {
+		size, bits, words int
+	}{
+		{7776, 128, 11},
+		{7776, 256, 21},
+		{1000, 128, 14},
+		{1000, 256, 27},
+		{1000, 32, 4},
+	} {
+		m := NewFromArrayOrDie(numberedWords(test.size))
+		for j := 0; j < 10; j++ {
+			words, err := m.GenerateEntropy(test.bits)
+			if err != nil {
+				t.Fatalf("Test %d: Failed to generate words: %v", i, err)
+			}
+			if len(words) != test.words {
+				t.Fatalf("Test %d: Word count doesn't match: Got %d, expected %d.",
+					i, len(words), test.words)
+			}
+			entropy, err := m.EntropyFromWords(words)
+			if err != nil {
+				t.Fatalf("Test %d: Failed to decode %q: %v", i, words, err)
+			}
+			again, _ := m.GenerateFromData(entropy)
+			if strings.Join(again, " ") != strings.Join(words, " ") {
+				t.Errorf("Test %d: Round trip doesn't match: Got %q, expected %q.",
+					i, again, words)
+			}
+		}
+		if words, err := m.GenerateWords(test.words); err != nil || len(words) != test.words {
+			t.Errorf("Test %d: Failed to generate %d words: %v", i, test.words, err)
+		}
+	}
+	if _, err := NewFromArrayOrDie(numberedWords(1000)).GenerateWords(13); !errors.Is(err, ErrWordCount) {
+		t.Errorf("Expected word count error, got %v.", err)
+	}
+
+	// Data of any length, where 6 and 7 bytes both take 6 words of a 1000 word
+	// list.
+	for _, size := range []int{1000, 7776} {
+		m := NewFromArrayOrDie(numberedWords(size))
+		counts := make(map[int]bool)
+		for length := 0; length <= 40; length++ {
+			data := make([]byte, length)
+			rand.Read(data)
+			if length > 1 {
+				data[0] = 0
+			}
+			words, err := m.GenerateFromData(data)
+			if err != nil {
+				t.Fatalf("Size %d: Failed to encode %d bytes: %v", size, length, err)
+			}
+			counts[len(words)] = true
+			decoded, err := m.decode(words)
+			if err != nil || !bytes.Equal(decoded, data) {
+				t.Errorf("Size %d: Round trip of %d bytes doesn't match: Got %x (%v), expected %x.",
+					size, length, decoded, err, data)
+			}
+		}
+		if len(counts) == 41 {
+			t.Errorf("Size %d: Expected some lengths to have the same word count.", size)
+		}
+	}
+	six, _ := NewFromArrayOrDie(numberedWords(1000)).GenerateFromData(make([]byte, 6))
+	seven, _ := NewFromArrayOrDie(numberedWords(1000)).GenerateFromData(make([]byte, 7))
+	if len(six) != 6 || len(seven) != 6 {
+		t.Errorf("Expected 6 words for 6 and 7 bytes, got %d and %d.", len(six), len(seven))
+	}
+
+	missing := append([]string(nil), words...)
+	missing[3] = Placeholder
+	phrases, err := m.Recover(context.Background(), missing, RecoveryOptions{})
+	if err != nil {
+		t.Fatalf("Failed to recover phrase: %v", err)
+	}
+	found := false
+	for _, p := range phrases {
+		found = found || strings.Join(p, " ") == strings.Join(words, " ")
+	}
+	if !found {
+		t.Errorf("Recovered phrases don't include the original.")
+	}
+}
+
+func TestChecksumBits(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	if err := m.SetChecksumBits(65); err == nil {
+		t.Errorf("Expected error for 65 bit checksum.")
+	}
+	if err := m.SetChecksumBits(16); err != nil {
+		t.Fatalf("Failed to set checksum length: %v", err)
+	}
+	data := make([]byte, 32)
+	rand.Read(data)
+	// 256 + 16 bits take 25 words of 11 bits.
+	words, err := m.GenerateFromData(data)
+	if err != nil {
+		t.Fatalf("Failed to encode data: %v", err)
+	}
+	if len(words) != 25 {
+		t.Fatalf("Word count doesn't match: Got %d, expected 25.", len(words))
+	}
+	decoded, err := m.EntropyFromWords(words)
+	if err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("Round trip doesn't match: Got %x (%v), expected %x.", decoded, err, data)
+	}
+
+	if err := m.SetChecksumBits(0); err != nil {
+		t.Fatalf("Failed to set checksum length: %v", err)
+	}
+	words, err = m.GenerateFromData(bytes.Repeat([]byte{0xff}, 4))
+	if err != nil {
+		t.Fatalf("Failed to encode data: %v", err)
+	}
+	// 2^33 - 1, the leading 1 bit and 32 one bits, is 2047, 2047 and 2047 in
+	// base 2048.
+	if got := strings.Join(words, " "); got != "zoo zoo zoo" {
+		t.Errorf("Words don't match: Got %q, expected %q.", got, "zoo zoo zoo")
+	}
+	words, err = m.GenerateFromData([]byte{0, 0xff})
+	if err != nil {
+		t.Fatalf("Failed to encode data: %v", err)
+	}
+	// 2^16 + 255 is 32 and 255 in base 2048.
+	if got := strings.Join(words, " "); got != "advice cable" {
+		t.Errorf("Words don't match: Got %q, expected %q.", got, "advice cable")
+	}
+	if decoded, err := m.EntropyFromWords(words); err != nil || !bytes.Equal(decoded, []byte{0, 0xff}) {
+		t.Errorf("Round trip doesn't match: Got %x (%v), expected 00ff.", decoded, err)
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	long := EFFLongDictionary()
+	if long.Size() != 7776 || EFFShortDictionary().Size() != 1296 ||
+		EFFShort1Dictionary().Size() != 1296 {
+		t.Fatalf("Unexpected EFF list sizes %d, %d and %d.", long.Size(),
+			EFFShortDictionary().Size(), EFFShort1Dictionary().Size())
+	}
+	// The first short list is for 1111 to 6666 on four dice.
+	for _, test := range []struct {
+		index int
+		word  string
+	}{{0, "acid"}, {1295, "zoom"}} {
+		if word, _ := EFFShort1Dictionary().Word(test.index); word != test.word {
+			t.Errorf("Word %d doesn't match: Got %q, expected %q.", test.index,
+				word, test.word)
+		}
+	}
+	passphrase, err := GeneratePassphrase(PassphraseOptions{Words: 6, Separator: " "})
+	if err != nil {
+		t.Fatalf("Failed to generate passphrase: %v", err)
+	}
+	words := strings.Split(passphrase, " ")
+	if len(words) != 6 {
+		t.Fatalf("Unexpected number of words in %q.", passphrase)
+	}
+	for _, word := range words {
+		if _, err := long.Index(word); err != nil {
+			t.Errorf("Word %q isn't in the EFF long list.", word)
+		}
+	}
+
+	zeros := bytes.NewReader(make([]byte, 100))
+	passphrase, err = GeneratePassphrase(PassphraseOptions{Words: 3, Separator: "-",
+		Capitalization: CapitalizeWords, Random: zeros})
+	if err != nil {
+		t.Fatalf("Failed to generate passphrase: %v", err)
+	}
+	if passphrase != "Abacus-Abacus-Abacus" {
+		t.Errorf("Passphrase doesn't match: Got %q, expected %q.", passphrase,
+			"Abacus-Abacus-Abacus")
+	}
+
+	mixed := PassphraseOptions{Words: 4, Dictionary: EFFShortDictionary(),
+		Separator: " ", Digits: 1, Symbols: 2}
+	passphrase, err = GeneratePassphrase(mixed)
+	if err != nil {
+		t.Fatalf("Failed to generate passphrase: %v", err)
+	}
+	var digits, symbols int
+	for _, part := range strings.Split(passphrase, " ") {
+		switch {
+		case strings.ContainsAny(part, "0123456789"):
+			digits++
+		case strings.ContainsAny(part, kDefaultSymbols):
+			symbols++
+		}
+	}
+	if digits != 1 || symbols != 2 {
+		t.Errorf("Unexpected digits and symbols in %q.", passphrase)
+	}
+
+	for i, test := range []struct {
+		opts PassphraseOptions
+		bits float64
+	}{
+		{PassphraseOptions{Words: 6}, 6 * math.Log2(7776)},
+		{PassphraseOptions{Words: 6, Capitalization: CapitalizeUpper}, 6 * math.Log2(7776)},
+		{PassphraseOptions{Words: 6, Capitalization: CapitalizeRandom}, 6 * math.Log2(2*7776)},
+		// 7!/(4! 1! 2!) = 105 orders.
+		{mixed, 4*math.Log2(1296) + math.Log2(10) + 2*math.Log2(12) + math.Log2(105)},
+	} {
+		bits, err := test.opts.Bits()
+		if err != nil {
+			t.Fatalf("Test %d: Failed to compute entropy: %v", i, err)
+		}
+		if math.Abs(bits-test.bits) > 1e-9 {
+			t.Errorf("Test %d: Entropy doesn't match: Got %f, expected %f.", i,
+				bits, test.bits)
+		}
+	}
+
+	for i, opts := range []PassphraseOptions{
+		{Words: 0},
+		{Words: 4, SymbolSet: "!1"},
+		{Words: 4, SymbolSet: "!!"},
+		{Words: 4, Digits: -1},
+	} {
+		if _, err := GeneratePassphrase(opts); err == nil {
+			t.Errorf("Test %d: Expected error for invalid options.", i)
+		}
+	}
+}
+
+func TestPGPWords(t *testing.T) {
+	fingerprint, _ := hex.DecodeString("E58294F2E9A227486E8B061B31CC528FD7FA3F19")
+	expected := "topmost Istanbul Pluto vagabond treadmill Pacific brackish dictator " +
+		"goldfish Medusa afflict bravado chatter revolver Dupont midsummer " +
+		"stopwatch whimsical cowbell bottomless"
+	words := PGPWords(fingerprint)
+	if got := strings.Join(words, " "); got != expected {
+		t.Fatalf("PGP words don't match: Got %q, expected %q.", got, expected)
+	}
+	data, err := DataFromPGPWords(strings.Fields(strings.ToUpper(expected)))
+	if err != nil {
+		t.Fatalf("Failed to decode PGP words: %v", err)
+	}
+	if !bytes.Equal(data, fingerprint) {
+		t.Errorf("Round trip doesn't match: Got %x, expected %x.", data, fingerprint)
+	}
+
+	all := make([]byte, 512)
+	for i := range all {
+		all[i] = byte(i / 2)
+	}
+	if data, err := DataFromPGPWords(PGPWords(all)); err != nil || !bytes.Equal(data, all) {
+		t.Errorf("Round trip of all bytes doesn't match: Got %x (%v).", data, err)
+	}
+
+	swapped := append([]string(nil), words...)
+	swapped[2], swapped[3] = swapped[3], swapped[2]
+	if _, err := DataFromPGPWords(swapped); !errors.Is(err, ErrWordOrder) {
+		t.Errorf("Expected word order error for swapped words, got %v.", err)
+	}
+	dropped := append(append([]string(nil), words[:5]...), words[6:]...)
+	if _, err := DataFromPGPWords(dropped); !errors.Is(err, ErrWordOrder) {
+		t.Errorf("Expected word order error for dropped word, got %v.", err)
+	}
+	if _, err := DataFromPGPWords([]string{"topmost", "zebra"}); !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("Expected unknown word error, got %v.", err)
+	}
+}
+
+func TestBytewords(t *testing.T) {
+	data := []byte{0, 1, 2, 128, 255}
+	for i, test := range []struct {
+		style    BytewordsStyle
+		expected string
+	}{
+		{BytewordsStandard, "able acid also lava zoom jade need echo taxi"},
+		{BytewordsURI, "able-acid-also-lava-zoom-jade-need-echo-taxi"},
+		{BytewordsMinimal, "aeadaolazmjendeoti"},
+	} {
+		encoded, err := Bytewords(data, test.style)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to encode: %v", i, err)
+		}
+		if encoded != test.expected {
+			t.Errorf("Test %d: Bytewords don't match: Got %q, expected %q.", i,
+				encoded, test.expected)
+		}
+		decoded, err := DataFromBytewords(strings.ToUpper(test.expected), test.style)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to decode: %v", i, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("Test %d: Round trip doesn't match: Got %x, expected %x.", i,
+				decoded, data)
+		}
+	}
+	if _, err := DataFromBytewords("able acid also lava zoom jade need echo tent", BytewordsStandard); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected checksum error, got %v.", err)
+	}
+	if _, err := DataFromBytewords("aeadaolazmjendeot", BytewordsMinimal); err == nil {
+		t.Errorf("Expected error for odd length.")
+	}
+	if _, err := DataFromBytewords("need echo taxi", BytewordsStandard); !errors.Is(err, ErrWordCount) {
+		t.Errorf("Expected word count error, got %v.", err)
+	}
+	for _, style := range []BytewordsStyle{BytewordsStandard, BytewordsURI, BytewordsMinimal} {
+		encoded, err := Bytewords(nil, style)
+		if err != nil {
+			t.Fatalf("Failed to encode empty data: %v", err)
+		}
+		decoded, err := DataFromBytewords(encoded, style)
+		if err != nil || len(decoded) != 0 {
+			t.Errorf("Unexpected empty data round trip of %q: Got %x (%v).",
+				encoded, decoded, err)
+		}
+	}
+
+	m := NewFromArrayOrDie(DefaultWordlist)
+	p, err := m.PhraseFromWords(strings.Fields("legal winner thank year wave sausage worth useful legal winner thank yellow"))
+	if err != nil {
+		t.Fatalf("Failed to create phrase: %v", err)
+	}
+	encoded, err := p.Bytewords(BytewordsMinimal)
+	if err != nil {
+		t.Fatalf("Failed to encode phrase: %v", err)
+	}
+	decoded, err := m.PhraseFromBytewords(encoded, BytewordsMinimal)
+	if err != nil {
+		t.Fatalf("Failed to decode phrase: %v", err)
+	}
+	if decoded.String() != p.String() {
+		t.Errorf("Phrase doesn't match: Got %q, expected %q.", decoded, p)
+	}
+}
@@ -0,0 +1,218 @@
+package mnemonic
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// Monero seeds encode 32 bits as 3 words from a 1626 word list, so a 32 byte
+// private spend key becomes 24 words. A 25th word repeats one of them, chosen
+// by the CRC-32 of the words' prefixes of a length fixed for each language,
+// as a checksum.
+//
+// Only the English wordlist is included. The others can be loaded from a file
+// or array, one word per line in the order of Monero's source, with the prefix
+// length of the language from MoneroPrefixLengths.
+
+const (
+	kMoneroWords   = 1626
+	kMoneroKeySize = 32
+)
+
+// MoneroPrefixLengths is the number of letters of each word that Monero's
+// checksum uses, by the language names of Monero's wordlists.
+var MoneroPrefixLengths = map[string]int{
+	"Chinese (simplified)": 1,
+	"Dutch":                4,
+	"English":              3,
+	"Esperanto":            4,
+	"French":               4,
+	"German":               4,
+	"Italian":              4,
+	"Japanese":             3,
+	"Lojban":               4,
+	"Portuguese":           4,
+	"Russian":              4,
+	"Spanish":              4,
+}
+
+var moneroEnglishDict = lazyWordlist(MoneroEnglishWordlist)
+
+// ed25519Order is the order l of the ed25519 base point, which Monero private
+// keys are reduced modulo.
+var ed25519Order = func() *big.Int {
+	l, _ := new(big.Int).SetString("27742317777372353535851937790883648493", 10)
+	return l.Add(l, new(big.Int).Lsh(big.NewInt(1), 252))
+}()
+
+// MoneroMnemonic converts Monero private spend keys to and from seed words.
+type MoneroMnemonic struct {
+	dict         *Dictionary
+	prefixLength int
+
+	// mutex protects entropy, the source of random data for generating
+	// keys, crypto/rand if nil.
+	mutex   sync.Mutex
+	entropy io.Reader
+}
+
+// NewMonero makes a Monero mnemonic with one of the 1626 word Monero lists,
+// whose checksum uses the first prefixLength letters of each word as given
+// by MoneroPrefixLengths.
+func NewMonero(dict *Dictionary, prefixLength int) (*MoneroMnemonic, error) {
+	if dict.Size() != kMoneroWords {
+		return nil, fmt.Errorf("Monero dictionaries have %d words (%d isn't)",
+			kMoneroWords, dict.Size())
+	}
+	if prefixLength < 1 {
+		return nil, fmt.Errorf("invalid Monero prefix length %d", prefixLength)
+	}
+	return &MoneroMnemonic{
+		dict:         dict,
+		prefixLength: prefixLength,
+	}, nil
+}
+
+// NewMoneroFromFile makes a Monero mnemonic with the wordlist in the file and
+// the prefix length of its language.
+func NewMoneroFromFile(path string, prefixLength int) (*MoneroMnemonic, error) {
+	dict, err := DictionaryFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMonero(dict, prefixLength)
+}
+
+// NewMoneroEnglish makes a Monero mnemonic with the built-in English wordlist.
+func NewMoneroEnglish() *MoneroMnemonic {
+	m, _ := NewMonero(moneroEnglishDict.get(), MoneroPrefixLengths["English"])
+	return m
+}
+
+// SetEntropySource replaces the source of random data used by Generate.
+func (m *MoneroMnemonic) SetEntropySource(r io.Reader) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.entropy = r
+}
+
+// checksumIndex picks which of the words is repeated as checksum.
+func (m *MoneroMnemonic) checksumIndex(words []string) int {
+	var prefixes []byte
+	for _, word := range words {
+		runes := []rune(word)
+		if len(runes) > m.prefixLength {
+			runes = runes[:m.prefixLength]
+		}
+		prefixes = append(prefixes, string(runes)...)
+	}
+	return int(crc32.ChecksumIEEE(prefixes) % uint32(len(words)))
+}
+
+// Encode converts the key, or other data of a multiple of 4 bytes, to words
+// with a checksum word at the end.
+func (m *MoneroMnemonic) Encode(key []byte) ([]string, error) {
+	if len(key) == 0 || len(key)%4 != 0 {
+		return nil, fmt.Errorf("data length must be divisible by 4 (%d isn't)",
+			len(key))
+	}
+	n := uint32(kMoneroWords)
+	words := make([]string, 0, len(key)/4*3+1)
+	for i := 0; i < len(key); i += 4 {
+		x := uint32(key[i]) | uint32(key[i+1])<<8 | uint32(key[i+2])<<16 |
+			uint32(key[i+3])<<24
+		w1 := x % n
+		w2 := (x/n + w1) % n
+		w3 := (x/n/n + w2) % n
+		for _, w := range []uint32{w1, w2, w3} {
+			word, err := m.dict.Word(int(w))
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, word)
+		}
+	}
+	return append(words, words[m.checksumIndex(words)]), nil
+}
+
+// Decode converts words back to the key, verifying the checksum word if it's
+// there. Words can be abbreviated to their unique prefixes.
+func (m *MoneroMnemonic) Decode(words []string) ([]byte, error) {
+	if len(words) < 3 || (len(words)%3 != 0 && len(words)%3 != 1) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+	expanded := make([]string, len(words))
+	indexes := make([]uint32, len(words))
+	for i, word := range words {
+		var err error
+		expanded[i], err = m.dict.Expand(word)
+		if err != nil {
+			return nil, fmt.Errorf("word %d: %w", i, err)
+		}
+		index, _ := m.dict.Index(expanded[i])
+		indexes[i] = uint32(index)
+	}
+	if len(words)%3 == 1 {
+		data := expanded[:len(words)-1]
+		if expanded[len(words)-1] != data[m.checksumIndex(data)] {
+			return nil, ErrChecksum
+		}
+		indexes = indexes[:len(words)-1]
+	}
+
+	n := uint32(kMoneroWords)
+	key := make([]byte, 0, len(indexes)/3*4)
+	for i := 0; i < len(indexes); i += 3 {
+		w1, w2, w3 := indexes[i], indexes[i+1], indexes[i+2]
+		x := uint64(w1) + uint64(n)*uint64((n-w1+w2)%n) +
+			uint64(n)*uint64(n)*uint64((n-w2+w3)%n)
+		if x%uint64(n) != uint64(w1) || x > 0xffffffff {
+			return nil, errors.New("invalid Monero word triplet")
+		}
+		key = append(key, byte(x), byte(x>>8), byte(x>>16), byte(x>>24))
+	}
+	return key, nil
+}
+
+// Generate makes a random private spend key, reduced modulo the ed25519
+// group order like Monero does, and returns its words.
+func (m *MoneroMnemonic) Generate() ([]string, []byte, error) {
+	random := make([]byte, kMoneroKeySize)
+	m.mutex.Lock()
+	source := m.entropy
+	if source == nil {
+		source = rand.Reader
+	}
+	_, err := io.ReadFull(source, random)
+	m.mutex.Unlock()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	key := moneroReduce(random)
+	words, err := m.Encode(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return words, key, nil
+}
+
+// moneroReduce reduces the 32 byte little-endian number modulo the ed25519
+// group order.
+func moneroReduce(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i, v := range b {
+		reversed[len(b)-1-i] = v
+	}
+	x := new(big.Int).SetBytes(reversed)
+	x.Mod(x, ed25519Order).FillBytes(reversed)
+	key := make([]byte, len(b))
+	for i, v := range reversed {
+		key[len(b)-1-i] = v
+	}
+	return key
+}
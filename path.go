@@ -0,0 +1,141 @@
+package mnemonic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DerivationPath is a list of child indexes leading from the master key to a
+// key in a hierarchical deterministic wallet, written as eg. "m/84'/0'/0'/0/5".
+type DerivationPath []uint32
+
+// Purpose is the first level of BIP-0043 derivation paths, identifying the
+// kind of wallet and so which addresses the keys are used for.
+type Purpose uint32
+
+const (
+	// BIP44 is for legacy pay-to-public-key-hash (P2PKH) addresses.
+	BIP44 Purpose = 44
+	// BIP49 is for segwit addresses nested in pay-to-script-hash
+	// (P2SH-P2WPKH).
+	BIP49 Purpose = 49
+	// BIP84 is for native segwit pay-to-witness-public-key-hash (P2WPKH)
+	// addresses.
+	BIP84 Purpose = 84
+	// BIP86 is for single key taproot (P2TR) addresses.
+	BIP86 Purpose = 86
+)
+
+// Coin types from SLIP-0044 used at the second level of derivation paths.
+const (
+	CoinBitcoin uint32 = 0
+	// CoinTestnet is used for all test networks, including regtest.
+	CoinTestnet uint32 = 1
+)
+
+// AccountPath returns the path of the account key, purpose'/coin'/account'.
+// Extended public keys shared with watch-only wallets are usually at this
+// level.
+func (p Purpose) AccountPath(coin, account uint32) DerivationPath {
+	return DerivationPath{
+		HardenedKeyStart + uint32(p),
+		HardenedKeyStart + coin,
+		HardenedKeyStart + account,
+	}
+}
+
+// Path returns the path of an address key,
+// purpose'/coin'/account'/change/index. Change is 0 for receiving addresses
+// and 1 for change addresses.
+func (p Purpose) Path(coin, account, change, index uint32) DerivationPath {
+	return append(p.AccountPath(coin, account), change, index)
+}
+
+// ParseDerivationPath parses a path like "m/84'/0'/0'/0/5". Hardened indexes
+// can be marked with ', h or H. The path must start with "m", which alone is
+// the master key.
+func ParseDerivationPath(s string) (DerivationPath, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q doesn't start with \"m\"", s)
+	}
+	if len(parts) > 256 {
+		return nil, fmt.Errorf("derivation path %q is longer than 255 levels", s)
+	}
+	path := make(DerivationPath, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := false
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") ||
+			strings.HasSuffix(part, "H") {
+			hardened = true
+			part = part[:len(part)-1]
+		}
+		// Signs and leading zeros are not allowed.
+		if part == "" || part[0] < '0' || part[0] > '9' ||
+			(len(part) > 1 && part[0] == '0') {
+			return nil, fmt.Errorf("invalid index %q in derivation path %q", part, s)
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || index >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid index %q in derivation path %q", part, s)
+		}
+		if hardened {
+			index += HardenedKeyStart
+		}
+		path = append(path, uint32(index))
+	}
+	return path, nil
+}
+
+// Format writes the path with hardened indexes marked by the given string,
+// usually "'" or "h".
+func (p DerivationPath) Format(hardened string) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range p {
+		b.WriteString("/")
+		if index >= HardenedKeyStart {
+			b.WriteString(strconv.FormatUint(uint64(index-HardenedKeyStart), 10))
+			b.WriteString(hardened)
+		} else {
+			b.WriteString(strconv.FormatUint(uint64(index), 10))
+		}
+	}
+	return b.String()
+}
+
+// String writes the path with hardened indexes marked by ', eg.
+// "m/84'/0'/0'/0/5".
+func (p DerivationPath) String() string {
+	return p.Format("'")
+}
+
+// Derive follows the path from the key, which is normally the master key.
+func (k *ExtendedKey) Derive(path DerivationPath) (*ExtendedKey, error) {
+	key := k
+	for i, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, fmt.Errorf("derive %v at level %d: %w", path, i+1, err)
+		}
+	}
+	return key, nil
+}
+
+// MasterKey generates the master key of the hierarchical deterministic wallet
+// with the phrase's seed for the password.
+func (p Phrase) MasterKey(password string) (*ExtendedKey, error) {
+	return NewMasterKey(p.Seed(password))
+}
+
+// DeriveKey derives the key at the path from the master key of the phrase's
+// seed for the password.
+func (p Phrase) DeriveKey(password string, path DerivationPath) (*ExtendedKey, error) {
+	master, err := p.MasterKey(password)
+	if err != nil {
+		return nil, err
+	}
+	return master.Derive(path)
+}
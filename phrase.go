@@ -0,0 +1,92 @@
+package mnemonic
+
+import (
+	"strings"
+)
+
+// Phrase is a list of words generated from entropy by a Mnemonic. It is
+// immutable, so unlike the words remembered by the Mnemonic it is safe to
+// pass around and use from several goroutines.
+type Phrase struct {
+	words   []string
+	entropy []byte
+	lang    Language
+	hasLang bool
+}
+
+// NewPhrase generates a phrase from the given number of bits of random data,
+// which must be divisible by 32.
+func (m *Mnemonic) NewPhrase(bits int) (Phrase, error) {
+	data, err := m.randomData(bits)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return m.PhraseFromData(data)
+}
+
+// PhraseFromData generates the phrase encoding the provided data.
+func (m *Mnemonic) PhraseFromData(data []byte) (Phrase, error) {
+	words, err := m.encode(data)
+	if err != nil {
+		return Phrase{}, err
+	}
+	entropy := make([]byte, len(data))
+	copy(entropy, data)
+	return Phrase{
+		words:   words,
+		entropy: entropy,
+		lang:    m.lang,
+		hasLang: m.hasLang,
+	}, nil
+}
+
+// PhraseFromWords validates a list of words, like EntropyFromWords, and
+// returns them as a phrase.
+func (m *Mnemonic) PhraseFromWords(words []string) (Phrase, error) {
+	entropy, err := m.EntropyFromWords(words)
+	if err != nil {
+		return Phrase{}, err
+	}
+	list := make([]string, len(words))
+	copy(list, words)
+	return Phrase{
+		words:   list,
+		entropy: entropy,
+		lang:    m.lang,
+		hasLang: m.hasLang,
+	}, nil
+}
+
+// Words returns a copy of the words in the phrase.
+func (p Phrase) Words() []string {
+	words := make([]string, len(p.words))
+	copy(words, p.words)
+	return words
+}
+
+// Entropy returns a copy of the data encoded by the phrase.
+func (p Phrase) Entropy() []byte {
+	entropy := make([]byte, len(p.entropy))
+	copy(entropy, p.entropy)
+	return entropy
+}
+
+// Language returns the language of the phrase, if it was generated using one
+// of the built-in wordlists.
+func (p Phrase) Language() (Language, bool) {
+	return p.lang, p.hasLang
+}
+
+// Seed generates the 512 bit key seed for the phrase and password.
+func (p Phrase) Seed(password string) []byte {
+	return SeedFromWordsPassword(p.words, password)
+}
+
+// String returns the words separated by spaces, or by ideographic spaces for
+// Japanese phrases as recommended by BIP-0039.
+func (p Phrase) String() string {
+	if p.hasLang && p.lang == Japanese {
+		return strings.Join(p.words, "　")
+	}
+	return ListToString(p.words)
+}
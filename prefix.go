@@ -0,0 +1,101 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrAmbiguousPrefix is returned when a prefix matches more than one word.
+var ErrAmbiguousPrefix = errors.New("prefix matches several words")
+
+// Expand returns the word in the dictionary starting with prefix. A complete
+// word is returned as is, even if it's also the start of longer words.
+func (d Dictionary) Expand(prefix string) (string, error) {
+	if _, err := d.Index(prefix); err == nil {
+		return prefix, nil
+	}
+	if prefix == "" {
+		return "", fmt.Errorf("%w: empty prefix", ErrAmbiguousPrefix)
+	}
+	match := ""
+	for _, word := range d.dict {
+		if !strings.HasPrefix(word, prefix) {
+			continue
+		}
+		if match != "" {
+			return "", fmt.Errorf("%w: %q is the start of both %q and %q",
+				ErrAmbiguousPrefix, prefix, match, word)
+		}
+		match = word
+	}
+	if match == "" {
+		return "", fmt.Errorf("%w: no word starts with %q", ErrUnknownWord,
+			prefix)
+	}
+	return match, nil
+}
+
+// Abbreviate returns the shortest start of the word that Expand will turn back
+// into the complete word.
+func (d Dictionary) Abbreviate(word string) (string, error) {
+	if _, err := d.Index(word); err != nil {
+		return "", fmt.Errorf("%w: %q", ErrUnknownWord, word)
+	}
+	runes := []rune(word)
+	for n := 1; n < len(runes); n++ {
+		prefix := string(runes[:n])
+		if expanded, err := d.Expand(prefix); err == nil && expanded == word {
+			return prefix, nil
+		}
+	}
+	return word, nil
+}
+
+// UniquePrefixLength returns the smallest number of letters n such that the
+// first n letters of every word are enough to identify it, counting words
+// shorter than n as complete. It is 4 for the BIP-0039 English wordlist.
+func (d Dictionary) UniquePrefixLength() int {
+	longest := 0
+	for _, word := range d.dict {
+		if n := len([]rune(word)); n > longest {
+			longest = n
+		}
+	}
+	for n := 1; n < longest; n++ {
+		seen := make(map[string]bool, len(d.dict))
+		unique := true
+		for _, word := range d.dict {
+			runes := []rune(word)
+			if len(runes) > n {
+				runes = runes[:n]
+			}
+			prefix := string(runes)
+			if seen[prefix] {
+				unique = false
+				break
+			}
+			seen[prefix] = true
+		}
+		if unique {
+			return n
+		}
+	}
+	return longest
+}
+
+// ParsePhrase splits a phrase into words, expanding any of them that are
+// abbreviated to a unique prefix, like the first four letters of BIP-0039
+// English words stored on steel backup plates. The checksum isn't verified.
+func (m *Mnemonic) ParsePhrase(phrase string) ([]string, error) {
+	fields := strings.Fields(phrase)
+	words := make([]string, len(fields))
+	for i, field := range fields {
+		word, err := m.dict.Expand(field)
+		if err != nil {
+			return nil, fmt.Errorf("word %d: %w", i, err)
+		}
+		words[i] = word
+	}
+	return words, nil
+}
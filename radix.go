@@ -0,0 +1,142 @@
+package mnemonic
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Dictionaries whose size N isn't a power of two, like the 7776 word Diceware
+// list, can't give each word a whole number of bits. Their words are instead
+// the base N digits, most significant first, of the data and checksum taken
+// as one big number, using the fewest words that can hold every value of that
+// many bits. Dictionaries of any size use this encoding once a checksum
+// length is set with SetChecksumBits.
+//
+// Data of any length can be encoded. As a word can hold more than a byte,
+// several lengths can take the same number of words, so a 1 bit is put in
+// front of the data. The length of the number then gives the length of the
+// data, including any leading zero bytes.
+
+const kMaxChecksumBits = 64
+
+// SetChecksumBits makes the mnemonic append a checksum of the given number of
+// bits (0 to 64) of the SHA-256 hash to the data, instead of one bit for each
+// 32 bits of data like BIP-0039. The words are then radix encoded even if the
+// dictionary size is a power of two, so they aren't BIP-0039 phrases. It must
+// be called before the mnemonic is used.
+func (m *Mnemonic) SetChecksumBits(bits int) error {
+	if bits < 0 || bits > kMaxChecksumBits {
+		return fmt.Errorf("checksum length must be 0 to %d bits (%d isn't)",
+			kMaxChecksumBits, bits)
+	}
+	m.fixedChecksum = true
+	m.checksumLength = bits
+	return nil
+}
+
+// radix tells whether the words are radix encoded rather than split into
+// bits.
+func (m *Mnemonic) radix() bool {
+	return m.wordLength == 0 || m.fixedChecksum
+}
+
+// checksumBitCount returns the length of the checksum for data of the given
+// number of bytes.
+func (m *Mnemonic) checksumBitCount(dataLength int) int {
+	if m.fixedChecksum {
+		return m.checksumLength
+	}
+	return dataLength / 4
+}
+
+// radixValueBits returns the length in bits of the number data of the given
+// number of bytes is encoded as: the leading 1 bit, the data and the checksum.
+func (m *Mnemonic) radixValueBits(dataLength int) int {
+	return 1 + 8*dataLength + m.checksumBitCount(dataLength)
+}
+
+// radixWordCount returns the number of words data of the given number of
+// bytes is encoded as, the smallest count with N^count ≥ 2^bits.
+func (m *Mnemonic) radixWordCount(dataLength int) int {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(m.radixValueBits(dataLength)))
+	size := big.NewInt(int64(m.dict.Size()))
+	count := 0
+	for power := big.NewInt(1); power.Cmp(limit) < 0; count++ {
+		power.Mul(power, size)
+	}
+	return count
+}
+
+// radixDataLengths returns the numbers of bytes that are encoded as the
+// given number of words, shortest first.
+func (m *Mnemonic) radixDataLengths(words int) []int {
+	var lengths []int
+	for length := 0; ; length++ {
+		count := m.radixWordCount(length)
+		if count == words {
+			lengths = append(lengths, length)
+		}
+		if count > words {
+			break
+		}
+	}
+	return lengths
+}
+
+// radixEncode converts data to the base N digits of the data and its
+// checksum.
+func (m *Mnemonic) radixEncode(data []byte) ([]string, error) {
+	checksumLength := uint(m.checksumBitCount(len(data)))
+	value := new(big.Int).SetBytes(data)
+	value.SetBit(value, 8*len(data), 1)
+	value.Lsh(value, checksumLength)
+	value.Or(value, new(big.Int).SetUint64(checksumBits(data, checksumLength)))
+
+	size := big.NewInt(int64(m.dict.Size()))
+	words := make([]string, m.radixWordCount(len(data)))
+	var index big.Int
+	for i := len(words) - 1; i >= 0; i-- {
+		value.DivMod(value, size, &index)
+		var err error
+		words[i], err = m.dict.Word(int(index.Int64()))
+		if err != nil {
+			return nil, fmt.Errorf("look up dictionary word with index %d: %v",
+				index.Int64(), err)
+		}
+	}
+	return words, nil
+}
+
+// radixDecode converts the word indexes back to data, verifying the checksum.
+func (m *Mnemonic) radixDecode(indexes []int) ([]byte, error) {
+	size := big.NewInt(int64(m.dict.Size()))
+	value := new(big.Int)
+	for _, index := range indexes {
+		value.Mul(value, size)
+		value.Add(value, big.NewInt(int64(index)))
+	}
+	// The leading 1 bit gives the length, which must be one encoded as this
+	// many words, since the words could otherwise start with zeros.
+	length := -1
+	for l := 0; m.radixValueBits(l) <= value.BitLen(); l++ {
+		if m.radixValueBits(l) == value.BitLen() {
+			length = l
+		}
+	}
+	if length < 0 || m.radixWordCount(length) != len(indexes) {
+		if len(m.radixDataLengths(len(indexes))) == 0 {
+			return nil, fmt.Errorf("%w: %d", ErrWordCount, len(indexes))
+		}
+		return nil, fmt.Errorf("%w: value doesn't match the word count", ErrChecksum)
+	}
+	checksumLength := uint(m.checksumBitCount(length))
+	mask := new(big.Int).Lsh(big.NewInt(1), checksumLength)
+	checksum := mask.And(value, mask.Sub(mask, big.NewInt(1))).Uint64()
+	value.Rsh(value, checksumLength)
+	value.SetBit(value, 8*length, 0)
+	data := value.FillBytes(make([]byte, length))
+	if checksumBits(data, checksumLength) != checksum {
+		return nil, ErrChecksum
+	}
+	return data, nil
+}
@@ -0,0 +1,200 @@
+package mnemonic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Placeholder marks a missing word in a phrase passed to Recover.
+const Placeholder = "?"
+
+// Number of combinations each worker claims at a time while recovering.
+const kRecoveryChunk = 4096
+
+// RecoveryOptions configures the search done by Recover.
+type RecoveryOptions struct {
+	// Candidates optionally lists the words to try at each position. Where
+	// an entry is empty, the word in the phrase is used, or every word in
+	// the dictionary if it's a Placeholder.
+	Candidates [][]string
+	// Password used to derive the seed passed to Match.
+	Password string
+	// Match is called for each phrase with a valid checksum, together with
+	// its seed, and decides whether it's the phrase searched for. If nil,
+	// all phrases with valid checksums are returned and no seeds derived.
+	Match func(words []string, seed []byte) bool
+	// Workers is the number of phrases checked in parallel. Defaults to the
+	// number of CPUs.
+	Workers int
+	// Progress, if set, is called regularly with the number of combinations
+	// tried so far and the total number to try.
+	Progress func(tried, total uint64)
+}
+
+// MatchSeedPrefix returns a match function for RecoveryOptions accepting
+// phrases whose seed starts with the given bytes, eg. a fingerprint noted
+// down from the original seed.
+func MatchSeedPrefix(prefix []byte) func([]string, []byte) bool {
+	return func(words []string, seed []byte) bool {
+		return bytes.HasPrefix(seed, prefix)
+	}
+}
+
+// MatchNickname returns a match function for RecoveryOptions accepting
+// phrases whose seed has the given nickname.
+func MatchNickname(nickname string) func([]string, []byte) bool {
+	return func(words []string, seed []byte) bool {
+		return Nickname(seed) == nickname
+	}
+}
+
+// checksumValid checks the checksum of the phrase given by word indexes,
+// without looking words up in the dictionary.
+func (m *Mnemonic) checksumValid(indexes []int) bool {
+	if m.radix() {
+		_, err := m.radixDecode(indexes)
+		return err == nil
+	}
+	f := bitField{}
+	for _, i := range indexes {
+		f.appendUint(uint64(i), uint(m.wordLength))
+	}
+	checksumLength := f.Size() / 33
+	dataLength := f.Size() - checksumLength
+	checksum, err := f.word(dataLength, checksumLength)
+	if err != nil {
+		return false
+	}
+	return checksumBits(f.Bytes()[:dataLength/8], uint(checksumLength)) == checksum
+}
+
+// Recover finds the phrases with a valid checksum that can be made by filling
+// in the missing words of a phrase, marked by Placeholder, or by choosing
+// among the candidate words given for each position in opts. If opts.Match is
+// set, only phrases accepted by it are returned. The search is spread over
+// all CPUs and stops early if ctx is cancelled, in which case the phrases
+// found so far are returned together with the context's error.
+func (m *Mnemonic) Recover(ctx context.Context, words []string, opts RecoveryOptions) ([][]string, error) {
+	n := len(words)
+	if !m.validWordCount(n) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, n)
+	}
+	if opts.Candidates != nil && len(opts.Candidates) != n {
+		return nil, fmt.Errorf("got candidates for %d positions, expected %d",
+			len(opts.Candidates), n)
+	}
+
+	// Word indexes to try at each position, and the total number of
+	// combinations.
+	choices := make([][]int, n)
+	total := uint64(1)
+	for i, word := range words {
+		var list []string
+		if opts.Candidates != nil {
+			list = opts.Candidates[i]
+		}
+		if len(list) == 0 {
+			if word == Placeholder {
+				choices[i] = make([]int, m.dict.Size())
+				for j := range choices[i] {
+					choices[i][j] = j
+				}
+			} else {
+				list = []string{word}
+			}
+		}
+		for _, w := range list {
+			index, err := m.wordIndex(w)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q at position %d", ErrUnknownWord,
+					w, i)
+			}
+			choices[i] = append(choices[i], index)
+		}
+		hi, lo := bits.Mul64(total, uint64(len(choices[i])))
+		if hi != 0 {
+			return nil, errors.New("too many combinations to try")
+		}
+		total = lo
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type result struct {
+		combination uint64
+		words       []string
+	}
+	var (
+		next, tried uint64
+		mutex       sync.Mutex
+		results     []result
+		wg          sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			indexes := make([]int, n)
+			for ctx.Err() == nil {
+				start := atomic.AddUint64(&next, kRecoveryChunk) - kRecoveryChunk
+				if start >= total {
+					return
+				}
+				end := start + kRecoveryChunk
+				if end > total {
+					end = total
+				}
+				for c := start; c < end; c++ {
+					// Decode the combination number with the last position
+					// changing fastest.
+					rest := c
+					for i := n - 1; i >= 0; i-- {
+						k := uint64(len(choices[i]))
+						indexes[i] = choices[i][rest%k]
+						rest /= k
+					}
+					if !m.checksumValid(indexes) {
+						continue
+					}
+					phrase := make([]string, n)
+					for i, index := range indexes {
+						phrase[i] = m.dict.dict[index]
+					}
+					if opts.Match != nil &&
+						!opts.Match(phrase, SeedFromWordsPassword(phrase, opts.Password)) {
+						continue
+					}
+					mutex.Lock()
+					results = append(results, result{c, phrase})
+					mutex.Unlock()
+				}
+				atomic.AddUint64(&tried, end-start)
+				if opts.Progress != nil {
+					mutex.Lock()
+					opts.Progress(atomic.LoadUint64(&tried), total)
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].combination < results[j].combination
+	})
+	phrases := make([][]string, len(results))
+	for i, r := range results {
+		phrases[i] = r.words
+	}
+	return phrases, ctx.Err()
+}
@@ -0,0 +1,139 @@
+package mnemonic
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Largest phrase for which every ordering of the words can be tried.
+const kMaxPermutationWords = 9
+
+// ReorderOptions configures the search done by RecoverOrder.
+type ReorderOptions struct {
+	// Permute tries every ordering of the words rather than just swapping
+	// two of them. Only supported for phrases of up to 9 words.
+	Permute bool
+	// Password used to derive the seed passed to Match.
+	Password string
+	// Match is called for each ordering with a valid checksum, together with
+	// its seed, and decides whether it's the phrase searched for. If nil,
+	// all orderings with valid checksums are returned.
+	Match func(words []string, seed []byte) bool
+}
+
+// inversions counts the pairs of words in a different order than in the
+// original phrase. Swapping neighbours counts as one, while swapping words
+// further apart counts as more, making it a measure of how likely a mistake
+// it is to have written the words in that order.
+func inversions(order []int) int {
+	count := 0
+	for i := range order {
+		for j := i + 1; j < len(order); j++ {
+			if order[i] > order[j] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// RecoverOrder finds orderings of the words that give a valid checksum, for
+// when words were written down out of order. By default every swap of two
+// words is tried, and with opts.Permute every possible ordering. The phrases
+// are returned with the least moved first, so a swap of neighbouring words
+// comes before a swap of words further apart. The phrase as given is included
+// if it's valid.
+func (m *Mnemonic) RecoverOrder(words []string, opts ReorderOptions) ([][]string, error) {
+	n := len(words)
+	if !m.validWordCount(n) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, n)
+	}
+	if opts.Permute && n > kMaxPermutationWords {
+		return nil, fmt.Errorf("can't try all orderings of %d words, at most %d supported",
+			n, kMaxPermutationWords)
+	}
+	indexes := make([]int, n)
+	for i, word := range words {
+		index, err := m.wordIndex(word)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q at position %d", ErrUnknownWord,
+				word, i)
+		}
+		indexes[i] = index
+	}
+
+	type candidate struct {
+		words []string
+		score int
+	}
+	var found []candidate
+	seen := make(map[string]bool)
+	candidateIndexes := make([]int, n)
+	try := func(order []int) {
+		for i, o := range order {
+			candidateIndexes[i] = indexes[o]
+		}
+		if !m.checksumValid(candidateIndexes) {
+			return
+		}
+		phrase := make([]string, n)
+		for i, o := range order {
+			phrase[i] = words[o]
+		}
+		// Swapping repeated words gives the same phrase more than once.
+		key := ListToString(phrase)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		if opts.Match != nil &&
+			!opts.Match(phrase, SeedFromWordsPassword(phrase, opts.Password)) {
+			return
+		}
+		found = append(found, candidate{phrase, inversions(order)})
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if opts.Permute {
+		// Heap's algorithm, generating each ordering from the previous one
+		// by a single swap.
+		c := make([]int, n)
+		try(order)
+		for i := 0; i < n; {
+			if c[i] < i {
+				if i%2 == 0 {
+					order[0], order[i] = order[i], order[0]
+				} else {
+					order[c[i]], order[i] = order[i], order[c[i]]
+				}
+				try(order)
+				c[i]++
+				i = 0
+			} else {
+				c[i] = 0
+				i++
+			}
+		}
+	} else {
+		try(order)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				order[i], order[j] = order[j], order[i]
+				try(order)
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score < found[j].score
+	})
+	phrases := make([][]string, len(found))
+	for i, c := range found {
+		phrases[i] = c.words
+	}
+	return phrases, nil
+}
@@ -0,0 +1,55 @@
+package mnemonic
+
+import (
+	"errors"
+
+	"gitlab.com/yawning/secp256k1-voi"
+)
+
+// Keys on the secp256k1 elliptic curve used by Bitcoin. The curve arithmetic
+// is done by secp256k1-voi, which is constant time, so private keys don't
+// leak through the time taken to derive public keys or children.
+
+// parseScalar parses a 32 byte big-endian number less than the order of the
+// curve, like a private key.
+func parseScalar(b []byte) (*secp256k1.Scalar, error) {
+	if len(b) != secp256k1.ScalarSize {
+		return nil, errors.New("scalar must be 32 bytes")
+	}
+	return secp256k1.NewScalarFromCanonicalBytes((*[secp256k1.ScalarSize]byte)(b))
+}
+
+// parsePrivateKey parses a 32 byte private key, which must be 1 to n - 1 where
+// n is the order of the curve.
+func parsePrivateKey(b []byte) (*secp256k1.Scalar, error) {
+	s, err := parseScalar(b)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsZero() == 1 {
+		return nil, errors.New("private key is zero")
+	}
+	return s, nil
+}
+
+// compressedPublicKey returns the 33 byte compressed public key of a private
+// key: the x coordinate of the point, prefixed by a byte indicating whether y
+// is even (2) or odd (3).
+func compressedPublicKey(private *secp256k1.Scalar) []byte {
+	return secp256k1.NewIdentityPoint().ScalarBaseMult(private).CompressedBytes()
+}
+
+// parseCompressed parses a compressed public key, which must be a point on
+// the curve.
+func parseCompressed(b []byte) (*secp256k1.Point, error) {
+	if len(b) != secp256k1.CompressedPointSize {
+		return nil, errors.New("invalid compressed public key")
+	}
+	return secp256k1.NewIdentityPoint().SetCompressedBytes(b)
+}
+
+// liftX finds the point with the given 32 byte x coordinate and an even y
+// coordinate.
+func liftX(x []byte) (*secp256k1.Point, error) {
+	return parseCompressed(append([]byte{2}, x...))
+}
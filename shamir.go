@@ -0,0 +1,113 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Shamir's secret sharing over GF(256), the field of bytes with the Rijndael
+// polynomial x⁸ + x⁴ + x³ + x + 1. Each byte of the secret is the value of a
+// random polynomial at one x coordinate, and each share its value at another.
+// Any threshold number of shares determine the polynomial and so the secret.
+
+var gfExp, gfLog = func() ([255]byte, [256]byte) {
+	var exp [255]byte
+	var log [256]byte
+	x := byte(1)
+	for i := range exp {
+		exp[i] = x
+		log[x] = byte(i)
+		// Multiply by the generator x + 1.
+		high := x & 0x80
+		x2 := x << 1
+		if high != 0 {
+			x2 ^= 0x1b
+		}
+		x ^= x2
+	}
+	return exp, log
+}()
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+int(gfLog[b]))%255]
+}
+
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[(int(gfLog[a])+255-int(gfLog[b]))%255]
+}
+
+// shamirPoint is the value y of a polynomial at x for each byte of a secret.
+type shamirPoint struct {
+	x byte
+	y []byte
+}
+
+// interpolate evaluates the polynomial through the points at x, using
+// Lagrange interpolation. The points must have distinct x coordinates and
+// values of the same length.
+func interpolate(points []shamirPoint, x byte) ([]byte, error) {
+	if len(points) == 0 {
+		return nil, errors.New("no points to interpolate")
+	}
+	length := len(points[0].y)
+	seen := make(map[byte]bool)
+	for _, p := range points {
+		if seen[p.x] {
+			return nil, fmt.Errorf("duplicate share index %d", p.x)
+		}
+		seen[p.x] = true
+		if len(p.y) != length {
+			return nil, errors.New("shares have different lengths")
+		}
+	}
+	if seen[x] {
+		for _, p := range points {
+			if p.x == x {
+				return append([]byte(nil), p.y...), nil
+			}
+		}
+	}
+	result := make([]byte, length)
+	for i, p := range points {
+		// The Lagrange basis polynomial for point i at x. Subtraction is
+		// xor in GF(256).
+		basis := byte(1)
+		for j, q := range points {
+			if i != j {
+				basis = gfMul(basis, gfDiv(x^q.x, p.x^q.x))
+			}
+		}
+		for k := range result {
+			result[k] ^= gfMul(basis, p.y[k])
+		}
+	}
+	return result, nil
+}
+
+// shamirSplit shares the secret among count shares at x = 1 ... count, any
+// threshold of which recover it at x = 0. The polynomial for each byte has
+// the secret byte as constant term and threshold - 1 coefficients taken from
+// random, which must hold (threshold - 1) · len(secret) bytes.
+func shamirSplit(secret []byte, threshold, count int, random []byte) []shamirPoint {
+	shares := make([]shamirPoint, count)
+	for i := range shares {
+		x := byte(i + 1)
+		y := make([]byte, len(secret))
+		for k := range secret {
+			// Horner's method, from the highest coefficient down.
+			v := byte(0)
+			for c := threshold - 2; c >= 0; c-- {
+				v = gfMul(v, x) ^ random[c*len(secret)+k]
+			}
+			y[k] = gfMul(v, x) ^ secret[k]
+		}
+		shares[i] = shamirPoint{x, y}
+	}
+	return shares
+}
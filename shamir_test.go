@@ -0,0 +1,200 @@
+package mnemonic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"testing"
+)
+
+func TestSLIP39Vectors(t *testing.T) {
+	file, err := ioutil.ReadFile("test_vectors_slip39.json")
+	if err != nil {
+		t.Fatalf("File error: %v\n", err)
+	}
+	var tests [][]interface{}
+	err = json.Unmarshal(file, &tests)
+	if err != nil {
+		t.Fatalf("File parsing error: %v\n", err)
+	}
+	for i, test := range tests {
+		var shares [][]string
+		for _, share := range test[1].([]interface{}) {
+			shares = append(shares, strings.Split(share.(string), " "))
+		}
+		expected := test[2].(string)
+		secret, err := CombineSLIP39(shares, "TREZOR")
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Test %d: Expected error for %q.", i, test[0])
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: Failed to combine shares for %q: %v", i, test[0], err)
+		}
+		if encoded := hex.EncodeToString(secret); encoded != expected {
+			t.Errorf("Test %d: Secret doesn't match: Got %q, expected %q.",
+				i, encoded, expected)
+		}
+		for j, words := range shares {
+			share, err := ParseSLIP39Share(words)
+			if err != nil {
+				t.Fatalf("Test %d: Failed to parse share %d: %v", i, j, err)
+			}
+			if encoded := strings.Join(share.Words(), " "); encoded != strings.Join(words, " ") {
+				t.Errorf("Test %d: Encoded share doesn't match: Got %q, expected %q.",
+					i, encoded, strings.Join(words, " "))
+			}
+		}
+	}
+}
+
+func TestSLIP39Split(t *testing.T) {
+	secret, _ := hex.DecodeString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	groups := []SLIP39Group{{1, 1}, {2, 3}, {3, 5}}
+	for _, extendable := range []bool{false, true} {
+		shares, err := SplitSLIP39(secret, 2, groups, SLIP39Options{
+			Passphrase: "TREZOR",
+			Extendable: extendable,
+		})
+		if err != nil {
+			t.Fatalf("Failed to split secret: %v", err)
+		}
+		sets := [][][]string{
+			{shares[0][0], shares[1][0], shares[1][2]},
+			{shares[2][4], shares[2][1], shares[2][0], shares[1][1], shares[1][0]},
+			{shares[0][0], shares[2][1], shares[2][2], shares[2][3]},
+		}
+		for i, set := range sets {
+			combined, err := CombineSLIP39(set, "TREZOR")
+			if err != nil {
+				t.Fatalf("Set %d: Failed to combine shares: %v", i, err)
+			}
+			if !bytes.Equal(combined, secret) {
+				t.Errorf("Set %d: Secret doesn't match: Got %x, expected %x.",
+					i, combined, secret)
+			}
+		}
+		combined, err := CombineSLIP39(sets[0], "")
+		if err != nil || bytes.Equal(combined, secret) {
+			t.Errorf("Expected another secret for another passphrase: %x, %v",
+				combined, err)
+		}
+		if _, err := CombineSLIP39([][]string{shares[1][0], shares[1][1]}, ""); !errors.Is(err, ErrSLIP39Shares) {
+			t.Errorf("Expected error for too few groups, got %v.", err)
+		}
+		if _, err := CombineSLIP39([][]string{shares[0][0], shares[1][0]}, ""); !errors.Is(err, ErrSLIP39Shares) {
+			t.Errorf("Expected error for too few members, got %v.", err)
+		}
+	}
+
+	if _, err := SplitSLIP39(secret[:15], 1, []SLIP39Group{{1, 1}}, SLIP39Options{}); err == nil {
+		t.Errorf("Expected error for odd secret length.")
+	}
+	long := append(append([]byte(nil), secret...), secret...)
+	shares, err := SplitSLIP39(long, 1, []SLIP39Group{{1, 1}, {1, 1}}, SLIP39Options{})
+	if err != nil {
+		t.Fatalf("Failed to split 64 byte secret: %v", err)
+	}
+	if combined, err := CombineSLIP39(shares[1], ""); err != nil || !bytes.Equal(combined, long) {
+		t.Errorf("64 byte secret doesn't match: Got %x (%v), expected %x.", combined, err, long)
+	}
+	share, err := ParseSLIP39Share(shares[0][0])
+	if err != nil {
+		t.Fatalf("Failed to parse share: %v", err)
+	}
+	share.GroupIndex = 2
+	if _, err := CombineSLIP39([][]string{share.Words()}, ""); !errors.Is(err, ErrSLIP39Shares) {
+		t.Errorf("Expected error for group index beyond the group count, got %v.", err)
+	}
+	if _, err := SplitSLIP39(secret, 1, []SLIP39Group{{1, 2}}, SLIP39Options{}); err == nil {
+		t.Errorf("Expected error for several members with threshold 1.")
+	}
+	if _, err := SplitSLIP39(secret, 2, []SLIP39Group{{1, 1}}, SLIP39Options{}); err == nil {
+		t.Errorf("Expected error for group threshold larger than group count.")
+	}
+}
+
+func TestGF256(t *testing.T) {
+	// 0x53 and 0xca are inverses in the AES field.
+	if p := gfMul(0x53, 0xca); p != 1 {
+		t.Errorf("Unexpected product %02x, expected 01.", p)
+	}
+	for a := 1; a < 256; a++ {
+		if q := gfDiv(gfMul(byte(a), 0x57), 0x57); q != byte(a) {
+			t.Fatalf("Division doesn't invert multiplication for %02x: Got %02x.", a, q)
+		}
+	}
+}
+
+func TestSplitPhrase(t *testing.T) {
+	m := NewFromArrayOrDie(DefaultWordlist)
+	for _, bits := range []int{128, 256} {
+		p, err := m.NewPhrase(bits)
+		if err != nil {
+			t.Fatalf("Failed to generate phrase: %v", err)
+		}
+		shares, err := m.SplitPhrase(p, 3, 5)
+		if err != nil {
+			t.Fatalf("Failed to split phrase: %v", err)
+		}
+		if expected := (bits + 32) * 33 / 32 / 11; len(shares[0]) != expected {
+			t.Errorf("Unexpected share length %d, expected %d.", len(shares[0]), expected)
+		}
+		for i, share := range shares {
+			if ok, err := m.VerifyChecksum(share); !ok || err != nil {
+				t.Errorf("Share %d: Invalid checksum (%v).", i, err)
+			}
+			indexes := make([]int, len(share))
+			for j, word := range share {
+				indexes[j], _ = m.wordIndex(word)
+			}
+			if !m.checksumValid(indexes) {
+				t.Errorf("Share %d: Invalid checksum of word indexes.", i)
+			}
+		}
+		sets := [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}, {3, 3, 1, 0}}
+		for i, set := range sets {
+			var subset [][]string
+			for _, j := range set {
+				subset = append(subset, shares[j])
+			}
+			combined, err := m.CombineShares(subset)
+			if err != nil {
+				t.Fatalf("Set %d: Failed to combine shares: %v", i, err)
+			}
+			if combined.String() != p.String() {
+				t.Errorf("Set %d: Phrase doesn't match: Got %q, expected %q.",
+					i, combined.String(), p.String())
+			}
+		}
+		if _, err := m.CombineShares(shares[:2]); err == nil {
+			t.Errorf("Expected error combining too few shares.")
+		}
+
+		other, _ := m.SplitPhrase(p, 3, 5)
+		mixed := [][]string{shares[0], shares[1], other[2]}
+		if _, err := m.CombineShares(mixed); !errors.Is(err, ErrShareMismatch) {
+			t.Errorf("Expected mismatch error for shares of different splits, got %v.", err)
+		}
+	}
+
+	p, _ := m.PhraseFromData(make([]byte, 16))
+	shares, err := m.SplitPhrase(p, 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to split phrase: %v", err)
+	}
+	combined, err := m.CombineShares(shares)
+	if err != nil || combined.String() != p.String() {
+		t.Errorf("Unexpected 1-of-1 phrase %q (%v), expected %q.",
+			combined.String(), err, p.String())
+	}
+	if _, err := m.SplitPhrase(p, 3, 2); err == nil {
+		t.Errorf("Expected error for threshold larger than count.")
+	}
+}
@@ -0,0 +1,134 @@
+package mnemonic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Shares of a phrase's entropy, for splitting existing BIP-0039 phrases
+// without moving funds to a new wallet. Each share is written like a phrase,
+// words from the same dictionary ending in a SHA-256 checksum, of 4 header
+// bytes followed by the share of the entropy: a random identifier common to
+// all shares (2 bytes), the threshold and the share index. A 12 word phrase
+// gives 15 word shares, and a 24 word phrase 27 word shares.
+
+// ErrShareMismatch is returned when combining shares that don't belong
+// together.
+var ErrShareMismatch = errors.New("shares don't belong together")
+
+const kShareHeaderLength = 4
+
+type phraseShare struct {
+	identifier uint16
+	threshold  int
+	point      shamirPoint
+}
+
+// SplitPhrase splits the phrase's entropy into count shares, threshold of
+// which are needed to recover the phrase with CombineShares. Up to 255 shares
+// can be made. The shares use the dictionary of m, normally the phrase's.
+//
+// Shares of phrases with less than 24 words have lengths that are also valid
+// for phrases, so take care not to use them as wallets.
+func (m *Mnemonic) SplitPhrase(p Phrase, threshold, count int) ([][]string, error) {
+	if threshold < 1 || threshold > count || count > 255 {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares", threshold, count)
+	}
+	secret := p.Entropy()
+	// Random coefficients, and the identifier at the end.
+	random, err := m.randomData(8 * ((threshold-1)*len(secret) + 4))
+	if err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(random[len(random)-4:])
+	shares := make([][]string, count)
+	for i, point := range shamirSplit(secret, threshold, count, random) {
+		data := binary.BigEndian.AppendUint16(nil, identifier)
+		data = append(data, byte(threshold), point.x)
+		shares[i], err = m.encode(append(data, point.y...))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+func (m *Mnemonic) parseShare(words []string) (phraseShare, error) {
+	data, err := m.decode(words)
+	if err != nil {
+		return phraseShare{}, err
+	}
+	if len(data) <= kShareHeaderLength {
+		return phraseShare{}, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+	s := phraseShare{
+		identifier: binary.BigEndian.Uint16(data),
+		threshold:  int(data[2]),
+		point:      shamirPoint{data[3], data[kShareHeaderLength:]},
+	}
+	if s.threshold == 0 || s.point.x == 0 {
+		return phraseShare{}, errors.New("invalid share header")
+	}
+	return s, nil
+}
+
+// CombineShares recovers the phrase from shares made by SplitPhrase. At least
+// the threshold number of shares are needed. Any shares beyond those must be
+// consistent with them.
+func (m *Mnemonic) CombineShares(shares [][]string) (Phrase, error) {
+	var parsed []phraseShare
+	for i, words := range shares {
+		s, err := m.parseShare(words)
+		if err != nil {
+			return Phrase{}, fmt.Errorf("share %d: %w", i, err)
+		}
+		if len(parsed) > 0 {
+			first := parsed[0]
+			if s.identifier != first.identifier || s.threshold != first.threshold ||
+				len(s.point.y) != len(first.point.y) {
+				return Phrase{}, fmt.Errorf("%w: share %d", ErrShareMismatch, i)
+			}
+		}
+		duplicate := false
+		for _, other := range parsed {
+			if other.point.x == s.point.x {
+				if !bytes.Equal(other.point.y, s.point.y) {
+					return Phrase{}, fmt.Errorf("%w: share index %d given twice with different values",
+						ErrShareMismatch, s.point.x)
+				}
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			parsed = append(parsed, s)
+		}
+	}
+	if len(parsed) == 0 {
+		return Phrase{}, errors.New("no shares")
+	}
+	threshold := parsed[0].threshold
+	if len(parsed) < threshold {
+		return Phrase{}, fmt.Errorf("%d shares given, %d needed", len(parsed), threshold)
+	}
+	points := make([]shamirPoint, threshold)
+	for i := range points {
+		points[i] = parsed[i].point
+	}
+	for _, extra := range parsed[threshold:] {
+		y, err := interpolate(points, extra.point.x)
+		if err != nil {
+			return Phrase{}, err
+		}
+		if !bytes.Equal(y, extra.point.y) {
+			return Phrase{}, fmt.Errorf("%w: share index %d is inconsistent with the others",
+				ErrShareMismatch, extra.point.x)
+		}
+	}
+	secret, err := interpolate(points, 0)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return m.PhraseFromData(secret)
+}
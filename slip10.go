@@ -0,0 +1,255 @@
+package mnemonic
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrNonHardenedEd25519 is returned when deriving a non-hardened child of an
+// ed25519 key, which SLIP-0010 doesn't support.
+var ErrNonHardenedEd25519 = errors.New("ed25519 keys only have hardened children")
+
+// SLIP10Curve is a curve supported by SLIP-0010 derivation of hierarchical
+// deterministic keys for other curves than secp256k1. Only Ed25519 and
+// NIST256P1, possibly with another name, are valid curves.
+type SLIP10Curve struct {
+	// Name is the HMAC key used to generate the master key from the seed.
+	// Changing it gives keys unrelated to those of other applications using
+	// the same seed.
+	Name string
+	kind slip10Kind
+}
+
+// slip10Kind identifies the curve arithmetic. The zero value is no curve, so
+// that curves not made from the package variables are rejected.
+type slip10Kind int
+
+const (
+	slip10Unknown slip10Kind = iota
+	slip10Ed25519
+	slip10NIST256P1
+)
+
+var (
+	// Ed25519 is the curve of ed25519 signatures. It only supports hardened
+	// derivation.
+	Ed25519 = SLIP10Curve{Name: "ed25519 seed", kind: slip10Ed25519}
+	// NIST256P1 is the NIST P-256 curve, also known as secp256r1.
+	NIST256P1 = SLIP10Curve{Name: "Nist256p1 seed", kind: slip10NIST256P1}
+)
+
+// WithName returns the curve with another HMAC key for the master key.
+func (c SLIP10Curve) WithName(name string) SLIP10Curve {
+	c.Name = name
+	return c
+}
+
+// SLIP10Key is a private key derived with SLIP-0010, together with the chain
+// code needed to derive its children and its position in the tree.
+type SLIP10Key struct {
+	curve SLIP10Curve
+	key   []byte
+	// publicKey is computed once when the key is derived.
+	publicKey         []byte
+	chainCode         []byte
+	depth             uint8
+	parentFingerprint uint32
+	childNumber       uint32
+}
+
+// order returns the order of the curve, nil for ed25519 where any 32 bytes
+// are a private key.
+func (c SLIP10Curve) order() *big.Int {
+	if c.kind == slip10NIST256P1 {
+		return elliptic.P256().Params().N
+	}
+	return nil
+}
+
+// validKey tells whether the 32 bytes can be used as a private key.
+func (c SLIP10Curve) validKey(k *big.Int) bool {
+	n := c.order()
+	return n == nil || (k.Sign() != 0 && k.Cmp(n) < 0)
+}
+
+// publicKey returns the 33 byte public key of a private key. For P-256 it is
+// compressed, and for ed25519 it is the 32 byte public key prefixed by a zero
+// byte.
+func (c SLIP10Curve) publicKey(key []byte) ([]byte, error) {
+	switch c.kind {
+	case slip10Ed25519:
+		if len(key) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid ed25519 key length %d", len(key))
+		}
+		public := ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey)
+		return append([]byte{0}, public...), nil
+	case slip10NIST256P1:
+		private, err := ecdh.P256().NewPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		// Uncompressed keys are 0x04 || x || y.
+		uncompressed := private.PublicKey().Bytes()
+		return append([]byte{2 + uncompressed[64]&1}, uncompressed[1:33]...), nil
+	}
+	return nil, fmt.Errorf("unknown SLIP-0010 curve %q", c.Name)
+}
+
+// NewSLIP10MasterKey generates the master key for the curve from a seed, eg.
+// one made by SeedFromWordsPassword.
+func NewSLIP10MasterKey(curve SLIP10Curve, seed []byte) (*SLIP10Key, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed length must be 16 to 64 bytes (%d isn't)",
+			len(seed))
+	}
+	if curve.kind == slip10Unknown {
+		return nil, fmt.Errorf("unknown SLIP-0010 curve %q, use Ed25519 or NIST256P1",
+			curve.Name)
+	}
+	sum := hmacSHA512([]byte(curve.Name), seed)
+	// Invalid keys are retried with the hash as seed.
+	for !curve.validKey(new(big.Int).SetBytes(sum[:32])) {
+		sum = hmacSHA512([]byte(curve.Name), sum)
+	}
+	publicKey, err := curve.publicKey(sum[:32])
+	if err != nil {
+		return nil, err
+	}
+	return &SLIP10Key{
+		curve:     curve,
+		key:       sum[:32],
+		publicKey: publicKey,
+		chainCode: sum[32:],
+	}, nil
+}
+
+// Child derives the child key with the given index, which is hardened if it
+// is HardenedKeyStart or larger. Ed25519 keys only have hardened children.
+func (k *SLIP10Key) Child(i uint32) (*SLIP10Key, error) {
+	if k.depth == 255 {
+		return nil, errors.New("maximum key depth reached")
+	}
+	publicKey, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	hardened := i >= HardenedKeyStart
+	if !hardened && k.curve.kind == slip10Ed25519 {
+		return nil, ErrNonHardenedEd25519
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		data = append(data, publicKey...)
+	}
+	data = binary.BigEndian.AppendUint32(data, i)
+	child := &SLIP10Key{
+		curve:             k.curve,
+		depth:             k.depth + 1,
+		parentFingerprint: k.Fingerprint(),
+		childNumber:       i,
+	}
+	n := k.curve.order()
+	for {
+		sum := hmacSHA512(k.chainCode, data)
+		child.chainCode = sum[32:]
+		if n == nil {
+			child.key = sum[:32]
+		} else if il := new(big.Int).SetBytes(sum[:32]); il.Cmp(n) < 0 {
+			key := il.Add(il, new(big.Int).SetBytes(k.key))
+			key.Mod(key, n)
+			if key.Sign() != 0 {
+				child.key = key.FillBytes(make([]byte, 32))
+			}
+		}
+		if child.key != nil {
+			child.publicKey, err = k.curve.publicKey(child.key)
+			if err != nil {
+				return nil, err
+			}
+			return child, nil
+		}
+		// Invalid keys are retried with the right half of the hash.
+		data = append([]byte{1}, sum[32:]...)
+		data = binary.BigEndian.AppendUint32(data, i)
+	}
+}
+
+// Derive follows the path from the key, which is normally the master key.
+func (k *SLIP10Key) Derive(path DerivationPath) (*SLIP10Key, error) {
+	key := k
+	for i, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, fmt.Errorf("derive %v at level %d: %w", path, i+1, err)
+		}
+	}
+	return key, nil
+}
+
+// Curve returns the curve of the key.
+func (k *SLIP10Key) Curve() SLIP10Curve {
+	return k.curve
+}
+
+// PrivateKey returns the 32 byte private key. For ed25519 this is the seed of
+// crypto/ed25519, see Ed25519PrivateKey.
+func (k *SLIP10Key) PrivateKey() []byte {
+	return append([]byte(nil), k.key...)
+}
+
+// Ed25519PrivateKey returns the private key for use with crypto/ed25519, if
+// this is an ed25519 key.
+func (k *SLIP10Key) Ed25519PrivateKey() (ed25519.PrivateKey, error) {
+	if k.curve.kind != slip10Ed25519 || len(k.key) != ed25519.SeedSize {
+		return nil, errors.New("not an ed25519 key")
+	}
+	return ed25519.NewKeyFromSeed(k.key), nil
+}
+
+// PublicKey returns the 33 byte public key. For P-256 it is compressed, and
+// for ed25519 it is the 32 byte public key prefixed by a zero byte. It fails
+// for keys not made by NewSLIP10MasterKey or derived from one.
+func (k *SLIP10Key) PublicKey() ([]byte, error) {
+	if k.publicKey == nil {
+		return nil, errors.New("invalid SLIP-0010 key")
+	}
+	return append([]byte(nil), k.publicKey...), nil
+}
+
+// ChainCode returns the 32 byte chain code.
+func (k *SLIP10Key) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// Depth returns the number of derivations from the master key.
+func (k *SLIP10Key) Depth() uint8 {
+	return k.depth
+}
+
+// ChildNumber returns the index the key was derived with, 0 for the master
+// key.
+func (k *SLIP10Key) ChildNumber() uint32 {
+	return k.childNumber
+}
+
+// ParentFingerprint returns the fingerprint of the parent key, 0 for the
+// master key.
+func (k *SLIP10Key) ParentFingerprint() uint32 {
+	return k.parentFingerprint
+}
+
+// Fingerprint identifies the key by the first 32 bits of the HASH160 of its
+// public key.
+func (k *SLIP10Key) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(hash160(k.publicKey))
+}
@@ -0,0 +1,468 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SLIP-0039 splits a master secret into shares in two levels: the secret is
+// shared among groups, each group's share among its members. Shares are
+// written with 10 bits per word from SLIP39Wordlist and end in a 30 bit
+// RS1024 checksum.
+
+var (
+	// ErrSLIP39Checksum is returned when the checksum of a SLIP-0039 share
+	// doesn't match.
+	ErrSLIP39Checksum = errors.New("invalid SLIP-0039 checksum")
+	// ErrSLIP39Shares is returned when a set of SLIP-0039 shares can't be
+	// combined, because they don't belong together or too few or too many
+	// are given.
+	ErrSLIP39Shares = errors.New("invalid set of SLIP-0039 shares")
+	// ErrSLIP39Digest is returned when combined shares give a secret that
+	// doesn't match its digest, meaning a share is wrong.
+	ErrSLIP39Digest = errors.New("SLIP-0039 shares don't match digest")
+)
+
+const (
+	kSLIP39RadixBits     = 10
+	kSLIP39ChecksumWords = 3
+	kSLIP39HeaderWords   = 4
+	kSLIP39MinWords      = 20
+	kSLIP39MaxShares     = 16
+	kSLIP39DigestIndex   = 254
+	kSLIP39SecretIndex   = 255
+	kSLIP39DigestLength  = 4
+	kSLIP39BaseIteration = 10000
+	kSLIP39Rounds        = 4
+)
+
+var slip39Dict = lazyWordlist(SLIP39Wordlist)
+
+func slip39Dictionary() *Dictionary {
+	return slip39Dict.get()
+}
+
+// SLIP39Share is a decoded SLIP-0039 share.
+type SLIP39Share struct {
+	// Identifier is a random 15 bit number shared by all shares of a secret.
+	Identifier uint16
+	// Extendable shares can be combined with shares made later for the same
+	// secret and passphrase, as the encryption doesn't depend on the
+	// identifier.
+	Extendable bool
+	// IterationExponent sets the number of PBKDF2 iterations of the
+	// encryption, 10000·2^IterationExponent.
+	IterationExponent uint8
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	// Value is this member's share of the group's share of the encrypted
+	// master secret.
+	Value []byte
+}
+
+func slip39Polymod(values []int) uint32 {
+	generator := [10]uint32{0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+		0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i := 0; i < 10; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func slip39Customization(extendable bool) []int {
+	s := "shamir"
+	if extendable {
+		s = "shamir_extendable"
+	}
+	values := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		values[i] = int(s[i])
+	}
+	return values
+}
+
+// ParseSLIP39Share decodes a share from its words, verifying the checksum.
+// Words can be abbreviated to their first four letters.
+func ParseSLIP39Share(words []string) (*SLIP39Share, error) {
+	if len(words) < kSLIP39MinWords {
+		return nil, fmt.Errorf("%w: SLIP-0039 shares have at least %d words (%d is too few)",
+			ErrWordCount, kSLIP39MinWords, len(words))
+	}
+	dict := slip39Dictionary()
+	indexes := make([]int, len(words))
+	for i, word := range words {
+		expanded, err := dict.Expand(word)
+		if err != nil {
+			return nil, fmt.Errorf("%w at position %d", err, i)
+		}
+		indexes[i], _ = dict.Index(expanded)
+	}
+
+	header := uint64(0)
+	for _, index := range indexes[:kSLIP39HeaderWords] {
+		header = header<<kSLIP39RadixBits | uint64(index)
+	}
+	s := &SLIP39Share{
+		Identifier:        uint16(header >> 25),
+		Extendable:        (header>>24)&1 == 1,
+		IterationExponent: uint8(header>>20) & 0xf,
+		GroupIndex:        int(header>>16) & 0xf,
+		GroupThreshold:    int(header>>12)&0xf + 1,
+		GroupCount:        int(header>>8)&0xf + 1,
+		MemberIndex:       int(header>>4) & 0xf,
+		MemberThreshold:   int(header)&0xf + 1,
+	}
+	if slip39Polymod(append(slip39Customization(s.Extendable), indexes...)) != 1 {
+		return nil, ErrSLIP39Checksum
+	}
+	if s.GroupThreshold > s.GroupCount {
+		return nil, fmt.Errorf("group threshold %d is larger than group count %d",
+			s.GroupThreshold, s.GroupCount)
+	}
+
+	valueIndexes := indexes[kSLIP39HeaderWords : len(indexes)-kSLIP39ChecksumWords]
+	valueBits := len(valueIndexes) * kSLIP39RadixBits
+	padding := valueBits % 16
+	if padding > 8 {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+	value := new(big.Int)
+	for _, index := range valueIndexes {
+		value.Lsh(value, kSLIP39RadixBits)
+		value.Or(value, big.NewInt(int64(index)))
+	}
+	length := (valueBits - padding) / 8
+	if value.BitLen() > length*8 {
+		return nil, errors.New("invalid SLIP-0039 padding")
+	}
+	s.Value = value.FillBytes(make([]byte, length))
+	return s, nil
+}
+
+// Words encodes the share as words from SLIP39Wordlist.
+func (s *SLIP39Share) Words() []string {
+	header := uint64(s.Identifier&0x7fff)<<25 |
+		uint64(s.IterationExponent&0xf)<<20 |
+		uint64(s.GroupIndex&0xf)<<16 |
+		uint64((s.GroupThreshold-1)&0xf)<<12 |
+		uint64((s.GroupCount-1)&0xf)<<8 |
+		uint64(s.MemberIndex&0xf)<<4 |
+		uint64((s.MemberThreshold-1)&0xf)
+	if s.Extendable {
+		header |= 1 << 24
+	}
+	indexes := make([]int, 0, kSLIP39MinWords)
+	for i := kSLIP39HeaderWords - 1; i >= 0; i-- {
+		indexes = append(indexes, int(header>>(i*kSLIP39RadixBits))&0x3ff)
+	}
+	valueWords := (len(s.Value)*8 + kSLIP39RadixBits - 1) / kSLIP39RadixBits
+	value := new(big.Int).SetBytes(s.Value)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(value, uint(i*kSLIP39RadixBits))
+		indexes = append(indexes, int(word.Int64()&0x3ff))
+	}
+	checksum := slip39Polymod(append(append(slip39Customization(s.Extendable),
+		indexes...), 0, 0, 0)) ^ 1
+	for i := kSLIP39ChecksumWords - 1; i >= 0; i-- {
+		indexes = append(indexes, int(checksum>>(i*kSLIP39RadixBits))&0x3ff)
+	}
+	words := make([]string, len(indexes))
+	for i, index := range indexes {
+		words[i] = SLIP39Wordlist[index]
+	}
+	return words
+}
+
+// slip39Feistel encrypts or decrypts the master secret with the passphrase,
+// using a four round Feistel network with PBKDF2 as round function.
+func slip39Feistel(secret []byte, passphrase string, exponent uint8,
+	identifier uint16, extendable, decrypt bool) []byte {
+	half := len(secret) / 2
+	l := append([]byte(nil), secret[:half]...)
+	r := append([]byte(nil), secret[half:]...)
+	var salt []byte
+	if !extendable {
+		salt = binary.BigEndian.AppendUint16([]byte("shamir"), identifier)
+	}
+	iterations := (kSLIP39BaseIteration << exponent) / kSLIP39Rounds
+	for round := 0; round < kSLIP39Rounds; round++ {
+		i := round
+		if decrypt {
+			i = kSLIP39Rounds - 1 - round
+		}
+		password := append([]byte{byte(i)}, passphrase...)
+		f := pbkdf2.Key(password, append(append([]byte(nil), salt...), r...),
+			iterations, len(r), sha256.New)
+		for j := range f {
+			f[j] ^= l[j]
+		}
+		l, r = r, f
+	}
+	return append(r, l...)
+}
+
+func checkSLIP39Passphrase(passphrase string) error {
+	for i := 0; i < len(passphrase); i++ {
+		if passphrase[i] < 32 || passphrase[i] > 126 {
+			return errors.New("SLIP-0039 passphrases must be printable ASCII")
+		}
+	}
+	return nil
+}
+
+func slip39Digest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:kSLIP39DigestLength]
+}
+
+// slip39SplitSecret shares the secret among count shares, threshold of which
+// are needed to recover it. Besides the secret at x = 255, the polynomial
+// passes through a digest of the secret at x = 254 to detect wrong shares.
+func slip39SplitSecret(threshold, count int, secret []byte, random io.Reader) ([]shamirPoint, error) {
+	if threshold < 1 || threshold > count || count > kSLIP39MaxShares {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares", threshold, count)
+	}
+	if threshold == 1 {
+		shares := make([]shamirPoint, count)
+		for i := range shares {
+			shares[i] = shamirPoint{byte(i), append([]byte(nil), secret...)}
+		}
+		return shares, nil
+	}
+	randomCount := threshold - 2
+	shares := make([]shamirPoint, randomCount, count)
+	for i := range shares {
+		shares[i] = shamirPoint{byte(i), make([]byte, len(secret))}
+		if _, err := io.ReadFull(random, shares[i].y); err != nil {
+			return nil, err
+		}
+	}
+	randomPart := make([]byte, len(secret)-kSLIP39DigestLength)
+	if _, err := io.ReadFull(random, randomPart); err != nil {
+		return nil, err
+	}
+	base := append(shares[:randomCount:randomCount],
+		shamirPoint{kSLIP39DigestIndex,
+			append(slip39Digest(randomPart, secret), randomPart...)},
+		shamirPoint{kSLIP39SecretIndex, secret})
+	for i := randomCount; i < count; i++ {
+		y, err := interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, shamirPoint{byte(i), y})
+	}
+	return shares, nil
+}
+
+// slip39RecoverSecret combines threshold shares made by slip39SplitSecret.
+func slip39RecoverSecret(threshold int, shares []shamirPoint) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].y, nil
+	}
+	secret, err := interpolate(shares, kSLIP39SecretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := interpolate(shares, kSLIP39DigestIndex)
+	if err != nil {
+		return nil, err
+	}
+	digest := digestShare[:kSLIP39DigestLength]
+	if !hmac.Equal(digest, slip39Digest(digestShare[kSLIP39DigestLength:], secret)) {
+		return nil, ErrSLIP39Digest
+	}
+	return secret, nil
+}
+
+// SLIP39Group is the number of member shares in a group, and how many of them
+// are needed to recover the group's share.
+type SLIP39Group struct {
+	Threshold, Count int
+}
+
+// SLIP39Options are optional settings for SplitSLIP39.
+type SLIP39Options struct {
+	// Passphrase encrypts the master secret. Recovering with another
+	// passphrase gives another, valid looking, secret.
+	Passphrase string
+	// IterationExponent makes the encryption take 2^IterationExponent times
+	// longer. It is 0 to 15.
+	IterationExponent uint8
+	// Extendable makes shares that can be combined with shares made later
+	// for the same secret and passphrase.
+	Extendable bool
+	// Random is the source of randomness, crypto/rand if nil.
+	Random io.Reader
+}
+
+// SplitSLIP39 splits the master secret, at least 16 bytes of even length, into
+// groups of shares. Shares of groupThreshold of the groups are needed to
+// recover the secret, and within each group its threshold of members. The
+// shares are returned as words for each member of each group.
+func SplitSLIP39(secret []byte, groupThreshold int, groups []SLIP39Group,
+	opts SLIP39Options) ([][][]string, error) {
+	if len(secret) < 16 || len(secret)%2 != 0 {
+		return nil, fmt.Errorf("master secret must be at least 16 bytes of even length (%d isn't)",
+			len(secret))
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("group threshold %d must be 1 to the number of groups, %d",
+			groupThreshold, len(groups))
+	}
+	for i, group := range groups {
+		if group.Threshold == 1 && group.Count > 1 {
+			return nil, fmt.Errorf("group %d has several members with threshold 1, use one member instead", i)
+		}
+	}
+	if opts.IterationExponent > 15 {
+		return nil, fmt.Errorf("iteration exponent %d is larger than 15",
+			opts.IterationExponent)
+	}
+	if err := checkSLIP39Passphrase(opts.Passphrase); err != nil {
+		return nil, err
+	}
+	random := opts.Random
+	if random == nil {
+		random = rand.Reader
+	}
+
+	var id [2]byte
+	if _, err := io.ReadFull(random, id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & 0x7fff
+	encrypted := slip39Feistel(secret, opts.Passphrase, opts.IterationExponent,
+		identifier, opts.Extendable, false)
+
+	groupShares, err := slip39SplitSecret(groupThreshold, len(groups), encrypted, random)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][][]string, len(groups))
+	for i, group := range groups {
+		memberShares, err := slip39SplitSecret(group.Threshold, group.Count,
+			groupShares[i].y, random)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i, err)
+		}
+		for _, member := range memberShares {
+			share := SLIP39Share{
+				Identifier:        identifier,
+				Extendable:        opts.Extendable,
+				IterationExponent: opts.IterationExponent,
+				GroupIndex:        i,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(member.x),
+				MemberThreshold:   group.Threshold,
+				Value:             member.y,
+			}
+			result[i] = append(result[i], share.Words())
+		}
+	}
+	return result, nil
+}
+
+// CombineSLIP39 recovers the master secret from shares, which must be exactly
+// the threshold number of members of exactly the threshold number of groups.
+func CombineSLIP39(shares [][]string, passphrase string) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("%w: no shares", ErrSLIP39Shares)
+	}
+	if err := checkSLIP39Passphrase(passphrase); err != nil {
+		return nil, err
+	}
+	var first *SLIP39Share
+	groups := make(map[int][]*SLIP39Share)
+	for i, words := range shares {
+		s, err := ParseSLIP39Share(words)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i, err)
+		}
+		if first == nil {
+			first = s
+		}
+		if s.Identifier != first.Identifier || s.Extendable != first.Extendable ||
+			s.IterationExponent != first.IterationExponent {
+			return nil, fmt.Errorf("%w: share %d doesn't begin like the others",
+				ErrSLIP39Shares, i)
+		}
+		if s.GroupThreshold != first.GroupThreshold || s.GroupCount != first.GroupCount {
+			return nil, fmt.Errorf("%w: share %d has other group parameters",
+				ErrSLIP39Shares, i)
+		}
+		if s.GroupIndex >= s.GroupCount {
+			return nil, fmt.Errorf("%w: share %d is in group %d of %d",
+				ErrSLIP39Shares, i, s.GroupIndex, s.GroupCount)
+		}
+		if len(s.Value) != len(first.Value) {
+			return nil, fmt.Errorf("%w: share %d has another length",
+				ErrSLIP39Shares, i)
+		}
+		duplicate := false
+		for _, other := range groups[s.GroupIndex] {
+			if other.MemberThreshold != s.MemberThreshold {
+				return nil, fmt.Errorf("%w: share %d has another member threshold than its group",
+					ErrSLIP39Shares, i)
+			}
+			if other.MemberIndex == s.MemberIndex {
+				if !bytes.Equal(other.Value, s.Value) {
+					return nil, fmt.Errorf("%w: member %d of group %d given twice",
+						ErrSLIP39Shares, s.MemberIndex, s.GroupIndex)
+				}
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			groups[s.GroupIndex] = append(groups[s.GroupIndex], s)
+		}
+	}
+
+	if len(groups) != first.GroupThreshold {
+		return nil, fmt.Errorf("%w: %d groups given, %d needed",
+			ErrSLIP39Shares, len(groups), first.GroupThreshold)
+	}
+	groupShares := make([]shamirPoint, 0, len(groups))
+	for index, members := range groups {
+		if len(members) != members[0].MemberThreshold {
+			return nil, fmt.Errorf("%w: %d shares of group %d given, %d needed",
+				ErrSLIP39Shares, len(members), index, members[0].MemberThreshold)
+		}
+		points := make([]shamirPoint, len(members))
+		for i, member := range members {
+			points[i] = shamirPoint{byte(member.MemberIndex), member.Value}
+		}
+		groupSecret, err := slip39RecoverSecret(members[0].MemberThreshold, points)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", index, err)
+		}
+		groupShares = append(groupShares, shamirPoint{byte(index), groupSecret})
+	}
+	encrypted, err := slip39RecoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return slip39Feistel(encrypted, passphrase, first.IterationExponent,
+		first.Identifier, first.Extendable, true), nil
+}
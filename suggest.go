@@ -0,0 +1,260 @@
+package mnemonic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Number of alternatives tried for each misspelled word when correcting a
+// phrase.
+const kCorrectionCandidates = 8
+
+// Most combinations of suggestions for the misspelled words that are tried
+// when correcting a phrase, enough for five misspelled words.
+const kMaxCorrectionCombinations = 1 << 15
+
+// ErrTooManyCorrections is returned by CorrectPhrase when too many words are
+// misspelled to try every combination of suggestions for them.
+var ErrTooManyCorrections = errors.New("too many misspelled words to correct")
+
+// Rows of a QWERTY keyboard, used to find keys next to each other.
+var keyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+type keyPosition struct {
+	row, col int
+}
+
+var keyboardPositions = func() map[rune]keyPosition {
+	positions := make(map[rune]keyPosition)
+	for row, keys := range keyboardRows {
+		for col, key := range keys {
+			positions[key] = keyPosition{row, col}
+		}
+	}
+	return positions
+}()
+
+// keysAdjacent reports whether a and b are next to each other on a QWERTY
+// keyboard, counting the keys diagonally above and below as adjacent.
+func keysAdjacent(a, b rune) bool {
+	pa, ok := keyboardPositions[a]
+	if !ok {
+		return false
+	}
+	pb, ok := keyboardPositions[b]
+	if !ok {
+		return false
+	}
+	switch pa.row - pb.row {
+	case 0:
+		return pa.col-pb.col == 1 || pb.col-pa.col == 1
+	case 1:
+		// The row above is shifted half a key to the left.
+		return pb.col == pa.col || pb.col == pa.col+1
+	case -1:
+		return pb.col == pa.col || pb.col == pa.col-1
+	}
+	return false
+}
+
+// editCost calculates a weighted Damerau-Levenshtein distance between a and b.
+// Insertions, deletions and substitutions cost 2, except substitutions of keys
+// next to each other on the keyboard and transpositions of neighbouring
+// letters which cost 1, as those are the most common typing mistakes.
+func editCost(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = 2 * i
+	}
+	for j := range d[0] {
+		d[0][j] = 2 * j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			sub := 0
+			if a[i-1] != b[j-1] {
+				sub = 2
+				if keysAdjacent(a[i-1], b[j-1]) {
+					sub = 1
+				}
+			}
+			cost := d[i-1][j-1] + sub
+			if c := d[i-1][j] + 2; c < cost {
+				cost = c
+			}
+			if c := d[i][j-1] + 2; c < cost {
+				cost = c
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				if c := d[i-2][j-2] + 1; c < cost {
+					cost = c
+				}
+			}
+			d[i][j] = cost
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func commonPrefix(a, b []rune) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+type suggestion struct {
+	index int
+	score int
+}
+
+// suggest scores every word in the dictionary against word and returns the
+// max best matches. The score is twice the edit cost, reduced by the length of
+// the shared prefix up to four letters, since the start of a word is usually
+// the part written most carefully.
+func (d Dictionary) suggest(word string, max int) []suggestion {
+	if max <= 0 {
+		return nil
+	}
+	w := []rune(strings.ToLower(word))
+	list := make([]suggestion, len(d.dict))
+	for i, candidate := range d.dict {
+		c := []rune(candidate)
+		prefix := commonPrefix(w, c)
+		if prefix > 4 {
+			prefix = 4
+		}
+		list[i] = suggestion{
+			index: i,
+			score: 2*editCost(w, c) - prefix,
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].score < list[j].score
+	})
+	if max < len(list) {
+		list = list[:max]
+	}
+	return list
+}
+
+// Suggest returns up to max words from the dictionary that are the most likely
+// intended when word was typed, best match first. Candidates are ranked by
+// edit distance, where mistyping a neighbouring key or swapping two letters
+// counts as a smaller mistake, and by how many of the first letters match. A
+// max of zero or less gives no words.
+func (d Dictionary) Suggest(word string, max int) []string {
+	var words []string
+	for _, s := range d.suggest(word, max) {
+		words = append(words, d.dict[s.index])
+	}
+	return words
+}
+
+// CorrectPhrase proposes up to max corrected versions of a phrase, best first.
+// Words not found in the dictionary are replaced by the closest suggestions,
+// and only combinations with a valid checksum are returned. If all words are
+// found but the checksum is invalid, corrections replacing a single word are
+// proposed instead. A valid phrase is returned unchanged. More than five
+// misspelled words fail with ErrTooManyCorrections.
+func (m *Mnemonic) CorrectPhrase(words []string, max int) ([][]string, error) {
+	if !m.validWordCount(len(words)) {
+		return nil, fmt.Errorf("%w: %d", ErrWordCount, len(words))
+	}
+
+	var unknown []int
+	for i, word := range words {
+		if _, err := m.wordIndex(word); err != nil {
+			unknown = append(unknown, i)
+		}
+	}
+	if len(unknown) == 0 {
+		ok, err := m.VerifyChecksum(words)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return [][]string{words}, nil
+		}
+	}
+
+	type correction struct {
+		words []string
+		score int
+	}
+	var found []correction
+	try := func(candidate []string, score int) error {
+		ok, err := m.VerifyChecksum(candidate)
+		if err != nil {
+			return err
+		}
+		if ok {
+			c := make([]string, len(candidate))
+			copy(c, candidate)
+			found = append(found, correction{c, score})
+		}
+		return nil
+	}
+
+	candidate := make([]string, len(words))
+	copy(candidate, words)
+	if len(unknown) == 0 {
+		// Assume a single word was mistyped as another valid word.
+		for i, word := range words {
+			for _, s := range m.dict.suggest(word, kCorrectionCandidates+1) {
+				if m.dict.dict[s.index] == word {
+					continue
+				}
+				candidate[i] = m.dict.dict[s.index]
+				if err := try(candidate, s.score); err != nil {
+					return nil, err
+				}
+			}
+			candidate[i] = word
+		}
+	} else {
+		suggestions := make([][]suggestion, len(unknown))
+		combinations := 1
+		for i, pos := range unknown {
+			suggestions[i] = m.dict.suggest(words[pos], kCorrectionCandidates)
+			combinations *= len(suggestions[i])
+			if combinations > kMaxCorrectionCombinations {
+				return nil, fmt.Errorf("%w: %d", ErrTooManyCorrections, len(unknown))
+			}
+		}
+		var walk func(i, score int) error
+		walk = func(i, score int) error {
+			if i == len(unknown) {
+				return try(candidate, score)
+			}
+			for _, s := range suggestions[i] {
+				candidate[unknown[i]] = m.dict.dict[s.index]
+				if err := walk(i+1, score+s.score); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := walk(0, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].score < found[j].score
+	})
+	var phrases [][]string
+	for i := 0; i < len(found) && i < max; i++ {
+		phrases = append(phrases, found[i].words)
+	}
+	return phrases, nil
+}
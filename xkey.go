@@ -0,0 +1,116 @@
+package mnemonic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// KeyVersion holds the version bytes prefixed to serialized extended keys,
+// which determine whether they start with eg. "xpub" or "zprv". SLIP-0132
+// defines versions telling wallets which addresses the keys are used for.
+type KeyVersion struct {
+	Public, Private uint32
+}
+
+var (
+	// VersionX is for mainnet keys (xpub/xprv) from BIP-0032, and by
+	// convention for BIP-0044 and BIP-0086 wallets.
+	VersionX = KeyVersion{0x0488b21e, 0x0488ade4}
+	// VersionY is for mainnet BIP-0049 P2SH-P2WPKH wallets (ypub/yprv).
+	VersionY = KeyVersion{0x049d7cb2, 0x049d7878}
+	// VersionZ is for mainnet BIP-0084 P2WPKH wallets (zpub/zprv).
+	VersionZ = KeyVersion{0x04b24746, 0x04b2430c}
+	// VersionT is for testnet keys (tpub/tprv).
+	VersionT = KeyVersion{0x043587cf, 0x04358394}
+	// VersionU is for testnet BIP-0049 P2SH-P2WPKH wallets (upub/uprv).
+	VersionU = KeyVersion{0x044a5262, 0x044a4e28}
+	// VersionV is for testnet BIP-0084 P2WPKH wallets (vpub/vprv).
+	VersionV = KeyVersion{0x045f1cf6, 0x045f18bc}
+)
+
+// KeyVersions lists all the known extended key versions.
+var KeyVersions = []KeyVersion{VersionX, VersionY, VersionZ, VersionT,
+	VersionU, VersionV}
+
+// Length of a serialized extended key, without the checksum.
+const kExtendedKeyLength = 78
+
+// Serialize encodes the key in the BIP-0032 format with the given version,
+// using Base58Check.
+func (k *ExtendedKey) Serialize(version KeyVersion) string {
+	b := make([]byte, 0, kExtendedKeyLength)
+	if k.private {
+		b = binary.BigEndian.AppendUint32(b, version.Private)
+	} else {
+		b = binary.BigEndian.AppendUint32(b, version.Public)
+	}
+	b = append(b, k.depth)
+	b = binary.BigEndian.AppendUint32(b, k.parentFingerprint)
+	b = binary.BigEndian.AppendUint32(b, k.childNumber)
+	b = append(b, k.chainCode...)
+	if k.private {
+		b = append(b, 0)
+	}
+	b = append(b, k.key...)
+	return Base58CheckEncode(b)
+}
+
+// String encodes the key as an xprv or xpub.
+func (k *ExtendedKey) String() string {
+	return k.Serialize(VersionX)
+}
+
+// ParseExtendedKey decodes a key serialized in the BIP-0032 format with one
+// of the KeyVersions, returning the key and its version.
+func ParseExtendedKey(s string) (*ExtendedKey, KeyVersion, error) {
+	b, err := Base58CheckDecode(s)
+	if err != nil {
+		return nil, KeyVersion{}, err
+	}
+	if len(b) != kExtendedKeyLength {
+		return nil, KeyVersion{}, fmt.Errorf("extended key length is %d, expected %d",
+			len(b), kExtendedKeyLength)
+	}
+	v := binary.BigEndian.Uint32(b[:4])
+	var version KeyVersion
+	private := false
+	for _, known := range KeyVersions {
+		if v == known.Public || v == known.Private {
+			version = known
+			private = v == known.Private
+		}
+	}
+	if version == (KeyVersion{}) {
+		return nil, KeyVersion{}, fmt.Errorf("unknown extended key version %08x", v)
+	}
+	k := &ExtendedKey{
+		depth:             b[4],
+		parentFingerprint: binary.BigEndian.Uint32(b[5:9]),
+		childNumber:       binary.BigEndian.Uint32(b[9:13]),
+		chainCode:         append([]byte(nil), b[13:45]...),
+		private:           private,
+	}
+	if k.depth == 0 && (k.parentFingerprint != 0 || k.childNumber != 0) {
+		return nil, KeyVersion{}, errors.New("master key with parent fingerprint or child number")
+	}
+	key := b[45:]
+	if private {
+		if key[0] != 0 {
+			return nil, KeyVersion{}, errors.New("private key not prefixed by zero")
+		}
+		d, err := parsePrivateKey(key[1:])
+		if err != nil {
+			return nil, KeyVersion{}, errors.New("private key out of range")
+		}
+		k.key = append([]byte(nil), key[1:]...)
+		k.publicKey = compressedPublicKey(d)
+	} else {
+		if _, err := parseCompressed(key); err != nil {
+			return nil, KeyVersion{}, err
+		}
+		k.key = append([]byte(nil), key...)
+		k.publicKey = k.key
+	}
+	return k, version, nil
+}